@@ -22,6 +22,12 @@ type StripeConfig struct {
 	SecretKey      string
 	PublishableKey string
 	WebhookSecret  string
+	// WebhookEventStorePath is the file used to persist processed webhook
+	// events; when empty the ledger is kept in memory
+	WebhookEventStorePath string
+	// WebhookReplayToken is the bearer token required to replay failed webhook
+	// events over HTTP; replay is disabled when empty
+	WebhookReplayToken string
 }
 
 // Load loads configuration from environment variables
@@ -32,9 +38,11 @@ func Load() *Config {
 			Host: getEnv("HOST", "localhost"),
 		},
 		Stripe: StripeConfig{
-			SecretKey:      getEnv("STRIPE_SECRET_KEY", ""),
-			PublishableKey: getEnv("STRIPE_PUBLISHABLE_KEY", ""),
-			WebhookSecret:  getEnv("STRIPE_WEBHOOK_SECRET", ""),
+			SecretKey:             getEnv("STRIPE_SECRET_KEY", ""),
+			PublishableKey:        getEnv("STRIPE_PUBLISHABLE_KEY", ""),
+			WebhookSecret:         getEnv("STRIPE_WEBHOOK_SECRET", ""),
+			WebhookEventStorePath: getEnv("STRIPE_WEBHOOK_EVENT_STORE_PATH", ""),
+			WebhookReplayToken:    getEnv("STRIPE_WEBHOOK_REPLAY_TOKEN", ""),
 		},
 	}
 
@@ -57,4 +65,4 @@ func getEnvAsInt(key string, defaultValue int) int {
 		}
 	}
 	return defaultValue
-} 
\ No newline at end of file
+}
@@ -37,11 +37,13 @@ func TestLoad(t *testing.T) {
 		{
 			name: "custom values",
 			envVars: map[string]string{
-				"PORT":                   "9000",
-				"HOST":                   "0.0.0.0",
-				"STRIPE_SECRET_KEY":      "sk_test_123",
-				"STRIPE_PUBLISHABLE_KEY": "pk_test_123",
-				"STRIPE_WEBHOOK_SECRET":  "whsec_test_123",
+				"PORT":                            "9000",
+				"HOST":                            "0.0.0.0",
+				"STRIPE_SECRET_KEY":               "sk_test_123",
+				"STRIPE_PUBLISHABLE_KEY":          "pk_test_123",
+				"STRIPE_WEBHOOK_SECRET":           "whsec_test_123",
+				"STRIPE_WEBHOOK_EVENT_STORE_PATH": "/var/lib/stripe-service/events.json",
+				"STRIPE_WEBHOOK_REPLAY_TOKEN":     "replay-secret",
 			},
 			expected: &Config{
 				Server: ServerConfig{
@@ -49,9 +51,11 @@ func TestLoad(t *testing.T) {
 					Host: "0.0.0.0",
 				},
 				Stripe: StripeConfig{
-					SecretKey:      "sk_test_123",
-					PublishableKey: "pk_test_123",
-					WebhookSecret:  "whsec_test_123",
+					SecretKey:             "sk_test_123",
+					PublishableKey:        "pk_test_123",
+					WebhookSecret:         "whsec_test_123",
+					WebhookEventStorePath: "/var/lib/stripe-service/events.json",
+					WebhookReplayToken:    "replay-secret",
 				},
 			},
 		},
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"stripe-service/internal/models"
+)
+
+// Checkout handlers
+
+// CreateCheckoutSession handles Checkout session creation requests
+func (h *StripeHandler) CreateCheckoutSession(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateCheckoutSessionRequest
+
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	session, err := h.stripeService.CreateCheckoutSession(r.Context(), &req)
+	if err != nil {
+		h.handleServiceError(w, err, "create checkout session", map[string]interface{}{
+			"mode":        req.Mode,
+			"customer_id": req.CustomerID,
+			"line_items":  len(req.LineItems),
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, session)
+}
+
+// GetCheckoutSession handles Checkout session retrieval requests
+func (h *StripeHandler) GetCheckoutSession(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	session, err := h.stripeService.GetCheckoutSession(r.Context(), sessionID)
+	if err != nil {
+		h.handleServiceError(w, err, "get checkout session", map[string]interface{}{
+			"session_id": sessionID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, session)
+}
+
+// ListCheckoutLineItems handles requests to list a Checkout session's line items
+func (h *StripeHandler) ListCheckoutLineItems(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	req := &models.ListCheckoutLineItemsRequest{SessionID: sessionID}
+	query := r.URL.Query()
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if limit, err := strconv.ParseInt(limitStr, 10, 64); err == nil {
+			req.Limit = limit
+		}
+	}
+
+	req.Cursor = query.Get("cursor")
+
+	lineItems, err := h.stripeService.ListCheckoutLineItems(r.Context(), req)
+	if err != nil {
+		h.handleServiceError(w, err, "list checkout line items", map[string]interface{}{
+			"session_id": sessionID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, lineItems)
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"stripe-service/internal/models"
+	"stripe-service/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+func (m *MockStripeService) CreateCheckoutSession(ctx context.Context, req *models.CreateCheckoutSessionRequest) (*models.CheckoutSession, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	if req.Mode != "setup" && len(req.LineItems) == 0 {
+		return nil, &service.ValidationError{Field: "line_items", Message: "must contain at least one item"}
+	}
+	if req.Mode == "setup" && req.Currency == "" {
+		return nil, &service.ValidationError{Field: "currency", Message: "is required in setup mode"}
+	}
+	return &models.CheckoutSession{
+		ID:         "cs_test123",
+		URL:        "https://checkout.stripe.com/c/pay/cs_test123",
+		Mode:       req.Mode,
+		Status:     "open",
+		CustomerID: req.CustomerID,
+		SuccessURL: req.SuccessURL,
+		ExpiresAt:  time.Now().Add(24 * time.Hour),
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) GetCheckoutSession(ctx context.Context, sessionID string) (*models.CheckoutSession, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.CheckoutSession{
+		ID:        sessionID,
+		Mode:      "payment",
+		Status:    "complete",
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) ListCheckoutLineItems(ctx context.Context, req *models.ListCheckoutLineItemsRequest) (*models.ListCheckoutLineItemsResponse, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.ListCheckoutLineItemsResponse{
+		LineItems: []models.CheckoutLineItemSummary{
+			{ID: "li_test1", Description: "Pro plan", PriceID: "price_123", Quantity: 1, Currency: "usd", AmountTotal: 1500},
+		},
+		HasMore: false,
+	}, nil
+}
+
+func TestStripeHandler_CreateCheckoutSession(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "payment session with price ID",
+			requestBody:    `{"mode":"payment","line_items":[{"price_id":"price_123","quantity":2}],"success_url":"https://example.com/success","allow_promotion_codes":true}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "payment session without line items",
+			requestBody:    `{"mode":"payment","success_url":"https://example.com/success"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "setup session without currency",
+			requestBody:    `{"mode":"setup","success_url":"https://example.com/success"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid mode",
+			requestBody:    `{"mode":"donation","success_url":"https://example.com/success"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			requestBody:    `{"mode":"setup","currency":"usd","success_url":"https://example.com/success"}`,
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "checkout error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("POST", "/checkout/sessions", strings.NewReader(tt.requestBody))
+			rr := httptest.NewRecorder()
+
+			handler.CreateCheckoutSession(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_GetCheckoutSessionAndLineItems(t *testing.T) {
+	tests := []struct {
+		name           string
+		sessionID      string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "valid session ID",
+			sessionID:      "cs_123",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "empty session ID",
+			sessionID:      "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			sessionID:      "cs_123",
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	handlers := map[string]func(h *StripeHandler) http.HandlerFunc{
+		"get":        func(h *StripeHandler) http.HandlerFunc { return h.GetCheckoutSession },
+		"line items": func(h *StripeHandler) http.HandlerFunc { return h.ListCheckoutLineItems },
+	}
+
+	for op, handlerFunc := range handlers {
+		for _, tt := range tests {
+			t.Run(op+" "+tt.name, func(t *testing.T) {
+				mockService := &MockStripeService{
+					shouldError: tt.shouldError,
+					errorMsg:    "checkout error",
+				}
+				handler := &StripeHandler{
+					stripeService: mockService,
+					validator:     validator.New(),
+				}
+
+				req := httptest.NewRequest("GET", "/checkout/sessions/"+tt.sessionID+"?limit=5", nil)
+				rr := httptest.NewRecorder()
+
+				req = mux.SetURLVars(req, map[string]string{"id": tt.sessionID})
+
+				handlerFunc(handler)(rr, req)
+
+				if status := rr.Code; status != tt.expectedStatus {
+					t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+				}
+			})
+		}
+	}
+}
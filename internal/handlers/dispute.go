@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"stripe-service/internal/models"
+)
+
+// Dispute handlers
+
+// ListDisputes handles dispute listing requests
+func (h *StripeHandler) ListDisputes(w http.ResponseWriter, r *http.Request) {
+	req := &models.ListDisputesRequest{}
+	query := r.URL.Query()
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if limit, err := strconv.ParseInt(limitStr, 10, 64); err == nil {
+			req.Limit = limit
+		}
+	}
+
+	req.Cursor = query.Get("cursor")
+	req.PaymentIntentID = query.Get("payment_intent")
+
+	var ok bool
+	if req.CreatedGte, ok = h.parseTimestampQuery(w, query, "created_gte"); !ok {
+		return
+	}
+	if req.CreatedLte, ok = h.parseTimestampQuery(w, query, "created_lte"); !ok {
+		return
+	}
+
+	disputes, err := h.stripeService.ListDisputes(r.Context(), req)
+	if err != nil {
+		h.handleServiceError(w, err, "list disputes", map[string]interface{}{
+			"limit":             req.Limit,
+			"cursor":            req.Cursor,
+			"payment_intent_id": req.PaymentIntentID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, disputes)
+}
+
+// GetDispute handles dispute retrieval requests
+func (h *StripeHandler) GetDispute(w http.ResponseWriter, r *http.Request) {
+	disputeID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	dispute, err := h.stripeService.GetDispute(r.Context(), disputeID)
+	if err != nil {
+		h.handleServiceError(w, err, "get dispute", map[string]interface{}{
+			"dispute_id": disputeID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, dispute)
+}
+
+// SubmitDisputeEvidence handles requests to stage or submit dispute evidence
+func (h *StripeHandler) SubmitDisputeEvidence(w http.ResponseWriter, r *http.Request) {
+	disputeID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.SubmitDisputeEvidenceRequest
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	dispute, err := h.stripeService.SubmitDisputeEvidence(r.Context(), disputeID, &req)
+	if err != nil {
+		h.handleServiceError(w, err, "submit dispute evidence", map[string]interface{}{
+			"dispute_id": disputeID,
+			"submit":     req.Submit,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, dispute)
+}
+
+// CloseDispute handles requests to accept a dispute as lost
+func (h *StripeHandler) CloseDispute(w http.ResponseWriter, r *http.Request) {
+	disputeID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	dispute, err := h.stripeService.CloseDispute(r.Context(), disputeID)
+	if err != nil {
+		h.handleServiceError(w, err, "close dispute", map[string]interface{}{
+			"dispute_id": disputeID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, dispute)
+}
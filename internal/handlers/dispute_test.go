@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"stripe-service/internal/models"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+func (m *MockStripeService) GetDispute(ctx context.Context, disputeID string) (*models.Dispute, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	dueBy := time.Now().Add(7 * 24 * time.Hour)
+	return &models.Dispute{
+		ID:            disputeID,
+		Amount:        1000,
+		Currency:      "usd",
+		Status:        "needs_response",
+		Reason:        "fraudulent",
+		EvidenceDueBy: &dueBy,
+		CreatedAt:     time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) ListDisputes(ctx context.Context, req *models.ListDisputesRequest) (*models.ListDisputesResponse, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.ListDisputesResponse{
+		Disputes: []models.Dispute{
+			{ID: "dp_test1", Amount: 1000, Currency: "usd", Status: "needs_response", PaymentIntentID: req.PaymentIntentID},
+		},
+		HasMore: false,
+	}, nil
+}
+
+func (m *MockStripeService) SubmitDisputeEvidence(ctx context.Context, disputeID string, req *models.SubmitDisputeEvidenceRequest) (*models.Dispute, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	status := "needs_response"
+	if req.Submit {
+		status = "under_review"
+	}
+	return &models.Dispute{
+		ID:          disputeID,
+		Amount:      1000,
+		Currency:    "usd",
+		Status:      status,
+		HasEvidence: true,
+		Evidence:    &req.Evidence,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) CloseDispute(ctx context.Context, disputeID string) (*models.Dispute, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.Dispute{
+		ID:        disputeID,
+		Amount:    1000,
+		Currency:  "usd",
+		Status:    "lost",
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func TestStripeHandler_ListDisputes(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "default list",
+			queryParams:    "",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "filter by payment intent and date",
+			queryParams:    "?payment_intent=pi_123&created_gte=1700000000",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid timestamp",
+			queryParams:    "?created_lte=soon",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			queryParams:    "",
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "list error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("GET", "/disputes"+tt.queryParams, nil)
+			rr := httptest.NewRecorder()
+
+			handler.ListDisputes(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_GetAndCloseDispute(t *testing.T) {
+	tests := []struct {
+		name           string
+		disputeID      string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "valid dispute ID",
+			disputeID:      "dp_123",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "empty dispute ID",
+			disputeID:      "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			disputeID:      "dp_123",
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	handlers := map[string]func(h *StripeHandler) http.HandlerFunc{
+		"get":   func(h *StripeHandler) http.HandlerFunc { return h.GetDispute },
+		"close": func(h *StripeHandler) http.HandlerFunc { return h.CloseDispute },
+	}
+
+	for op, handlerFunc := range handlers {
+		for _, tt := range tests {
+			t.Run(op+" "+tt.name, func(t *testing.T) {
+				mockService := &MockStripeService{
+					shouldError: tt.shouldError,
+					errorMsg:    "dispute error",
+				}
+				handler := &StripeHandler{
+					stripeService: mockService,
+					validator:     validator.New(),
+				}
+
+				req := httptest.NewRequest("GET", "/disputes/"+tt.disputeID, nil)
+				rr := httptest.NewRecorder()
+
+				req = mux.SetURLVars(req, map[string]string{"id": tt.disputeID})
+
+				handlerFunc(handler)(rr, req)
+
+				if status := rr.Code; status != tt.expectedStatus {
+					t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+				}
+			})
+		}
+	}
+}
+
+func TestStripeHandler_SubmitDisputeEvidence(t *testing.T) {
+	tests := []struct {
+		name           string
+		disputeID      string
+		requestBody    string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "stage evidence",
+			disputeID:      "dp_123",
+			requestBody:    `{"evidence":{"product_description":"Annual plan","receipt":"file_123"}}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "submit evidence",
+			disputeID:      "dp_123",
+			requestBody:    `{"evidence":{"uncategorized_text":"Customer used the service"},"submit":true}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid customer email",
+			disputeID:      "dp_123",
+			requestBody:    `{"evidence":{"customer_email_address":"not-an-email"}}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty dispute ID",
+			disputeID:      "",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			disputeID:      "dp_123",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			disputeID:      "dp_123",
+			requestBody:    `{"evidence":{"product_description":"Annual plan"}}`,
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "evidence error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("POST", "/disputes/"+tt.disputeID+"/evidence", strings.NewReader(tt.requestBody))
+			rr := httptest.NewRecorder()
+
+			req = mux.SetURLVars(req, map[string]string{"id": tt.disputeID})
+
+			handler.SubmitDisputeEvidence(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
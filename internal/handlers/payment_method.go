@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"stripe-service/internal/models"
+	"stripe-service/internal/service"
+)
+
+// Payment method handlers
+
+// ListPaymentMethods handles requests to list a customer's payment methods
+func (h *StripeHandler) ListPaymentMethods(w http.ResponseWriter, r *http.Request) {
+	customerID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	req := &models.ListPaymentMethodsRequest{CustomerID: customerID}
+	query := r.URL.Query()
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if limit, err := strconv.ParseInt(limitStr, 10, 64); err == nil {
+			req.Limit = limit
+		}
+	}
+
+	req.Type = query.Get("type")
+	req.Cursor = query.Get("cursor")
+
+	paymentMethods, err := h.stripeService.ListPaymentMethods(r.Context(), req)
+	if err != nil {
+		h.handleServiceError(w, err, "list payment methods", map[string]interface{}{
+			"customer_id": customerID,
+			"type":        req.Type,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, paymentMethods)
+}
+
+// GetPaymentMethod handles payment method retrieval requests
+func (h *StripeHandler) GetPaymentMethod(w http.ResponseWriter, r *http.Request) {
+	paymentMethodID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	paymentMethod, err := h.stripeService.GetPaymentMethod(r.Context(), paymentMethodID)
+	if err != nil {
+		h.handleServiceError(w, err, "get payment method", map[string]interface{}{
+			"payment_method_id": paymentMethodID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, paymentMethod)
+}
+
+// AttachPaymentMethod handles requests to attach a payment method to a customer
+func (h *StripeHandler) AttachPaymentMethod(w http.ResponseWriter, r *http.Request) {
+	paymentMethodID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.AttachPaymentMethodRequest
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	paymentMethod, err := h.stripeService.AttachPaymentMethod(r.Context(), paymentMethodID, &req)
+
+	// The payment method is attached but is not the default; report both so the
+	// client can retry or set the default separately
+	var partialErr *service.PartialFailureError
+	if errors.As(err, &partialErr) {
+		log.Printf("Service error - Operation: attach payment method, Error: %v, PaymentMethodID: %s, CustomerID: %s", err, paymentMethodID, req.CustomerID)
+		h.writeJSON(w, http.StatusInternalServerError, map[string]interface{}{
+			"error":          "Payment method attached but could not be set as the default",
+			"payment_method": paymentMethod,
+		})
+		return
+	}
+
+	if err != nil {
+		h.handleServiceError(w, err, "attach payment method", map[string]interface{}{
+			"payment_method_id": paymentMethodID,
+			"customer_id":       req.CustomerID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, paymentMethod)
+}
+
+// DetachPaymentMethod handles requests to detach a payment method from its customer
+func (h *StripeHandler) DetachPaymentMethod(w http.ResponseWriter, r *http.Request) {
+	paymentMethodID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	paymentMethod, err := h.stripeService.DetachPaymentMethod(r.Context(), paymentMethodID)
+	if err != nil {
+		h.handleServiceError(w, err, "detach payment method", map[string]interface{}{
+			"payment_method_id": paymentMethodID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, paymentMethod)
+}
+
+// SetDefaultPaymentMethod handles requests to change a customer's default payment method
+func (h *StripeHandler) SetDefaultPaymentMethod(w http.ResponseWriter, r *http.Request) {
+	customerID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.SetDefaultPaymentMethodRequest
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	customer, err := h.stripeService.SetDefaultPaymentMethod(r.Context(), customerID, &req)
+	if err != nil {
+		h.handleServiceError(w, err, "set default payment method", map[string]interface{}{
+			"customer_id":       customerID,
+			"payment_method_id": req.PaymentMethodID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, customer)
+}
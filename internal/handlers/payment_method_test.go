@@ -0,0 +1,329 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"stripe-service/internal/models"
+	"stripe-service/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+func (m *MockStripeService) ListPaymentMethods(ctx context.Context, req *models.ListPaymentMethodsRequest) (*models.ListPaymentMethodsResponse, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.ListPaymentMethodsResponse{
+		PaymentMethods: []models.PaymentMethod{
+			{
+				ID:         "pm_test1",
+				Type:       "card",
+				CustomerID: req.CustomerID,
+				Card:       &models.PaymentCard{Brand: "visa", Last4: "4242", ExpMonth: 12, ExpYear: 2030},
+			},
+		},
+		HasMore: false,
+	}, nil
+}
+
+func (m *MockStripeService) GetPaymentMethod(ctx context.Context, paymentMethodID string) (*models.PaymentMethod, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.PaymentMethod{
+		ID:        paymentMethodID,
+		Type:      "card",
+		Card:      &models.PaymentCard{Brand: "visa", Last4: "4242", ExpMonth: 12, ExpYear: 2030, WalletType: "apple_pay"},
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) AttachPaymentMethod(ctx context.Context, paymentMethodID string, req *models.AttachPaymentMethodRequest) (*models.PaymentMethod, error) {
+	paymentMethod := &models.PaymentMethod{
+		ID:         paymentMethodID,
+		Type:       "card",
+		CustomerID: req.CustomerID,
+		CreatedAt:  time.Now(),
+	}
+	if m.shouldError && req.SetAsDefault {
+		return paymentMethod, &service.PartialFailureError{Step: "set_as_default", Err: errors.New(m.errorMsg)}
+	}
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return paymentMethod, nil
+}
+
+func (m *MockStripeService) DetachPaymentMethod(ctx context.Context, paymentMethodID string) (*models.PaymentMethod, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.PaymentMethod{
+		ID:        paymentMethodID,
+		Type:      "card",
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) SetDefaultPaymentMethod(ctx context.Context, customerID string, req *models.SetDefaultPaymentMethodRequest) (*models.Customer, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.Customer{
+		ID:                     customerID,
+		Email:                  "test@example.com",
+		DefaultPaymentMethodID: req.PaymentMethodID,
+		CreatedAt:              time.Now(),
+		UpdatedAt:              time.Now(),
+	}, nil
+}
+
+func TestStripeHandler_ListPaymentMethods(t *testing.T) {
+	tests := []struct {
+		name           string
+		customerID     string
+		queryParams    string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "list card payment methods",
+			customerID:     "cus_123",
+			queryParams:    "?type=card&limit=5",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "empty customer ID",
+			customerID:     "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			customerID:     "cus_123",
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "list error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("GET", "/customers/"+tt.customerID+"/payment-methods"+tt.queryParams, nil)
+			rr := httptest.NewRecorder()
+
+			req = mux.SetURLVars(req, map[string]string{"id": tt.customerID})
+
+			handler.ListPaymentMethods(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_GetAndDetachPaymentMethod(t *testing.T) {
+	tests := []struct {
+		name            string
+		paymentMethodID string
+		shouldError     bool
+		expectedStatus  int
+	}{
+		{
+			name:            "valid payment method ID",
+			paymentMethodID: "pm_123",
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name:            "empty payment method ID",
+			paymentMethodID: "",
+			expectedStatus:  http.StatusBadRequest,
+		},
+		{
+			name:            "service error",
+			paymentMethodID: "pm_123",
+			shouldError:     true,
+			expectedStatus:  http.StatusInternalServerError,
+		},
+	}
+
+	handlers := map[string]func(h *StripeHandler) http.HandlerFunc{
+		"get":    func(h *StripeHandler) http.HandlerFunc { return h.GetPaymentMethod },
+		"detach": func(h *StripeHandler) http.HandlerFunc { return h.DetachPaymentMethod },
+	}
+
+	for op, handlerFunc := range handlers {
+		for _, tt := range tests {
+			t.Run(op+" "+tt.name, func(t *testing.T) {
+				mockService := &MockStripeService{
+					shouldError: tt.shouldError,
+					errorMsg:    "payment method error",
+				}
+				handler := &StripeHandler{
+					stripeService: mockService,
+					validator:     validator.New(),
+				}
+
+				req := httptest.NewRequest("GET", "/payment-methods/"+tt.paymentMethodID, nil)
+				rr := httptest.NewRecorder()
+
+				req = mux.SetURLVars(req, map[string]string{"id": tt.paymentMethodID})
+
+				handlerFunc(handler)(rr, req)
+
+				if status := rr.Code; status != tt.expectedStatus {
+					t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+				}
+			})
+		}
+	}
+}
+
+func TestStripeHandler_AttachPaymentMethod(t *testing.T) {
+	tests := []struct {
+		name            string
+		paymentMethodID string
+		requestBody     string
+		shouldError     bool
+		expectedStatus  int
+		expectedBody    string
+	}{
+		{
+			name:            "attach and set as default",
+			paymentMethodID: "pm_123",
+			requestBody:     `{"customer_id":"cus_123","set_as_default":true}`,
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name:            "missing customer",
+			paymentMethodID: "pm_123",
+			requestBody:     `{}`,
+			expectedStatus:  http.StatusBadRequest,
+		},
+		{
+			name:            "empty payment method ID",
+			paymentMethodID: "",
+			requestBody:     `{"customer_id":"cus_123"}`,
+			expectedStatus:  http.StatusBadRequest,
+		},
+		{
+			name:            "service error",
+			paymentMethodID: "pm_123",
+			requestBody:     `{"customer_id":"cus_123"}`,
+			shouldError:     true,
+			expectedStatus:  http.StatusInternalServerError,
+			expectedBody:    "Failed to attach payment method",
+		},
+		{
+			name:            "attached but set as default failed",
+			paymentMethodID: "pm_123",
+			requestBody:     `{"customer_id":"cus_123","set_as_default":true}`,
+			shouldError:     true,
+			expectedStatus:  http.StatusInternalServerError,
+			expectedBody:    `"payment_method":{"id":"pm_123"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "attach error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("POST", "/payment-methods/"+tt.paymentMethodID+"/attach", strings.NewReader(tt.requestBody))
+			rr := httptest.NewRecorder()
+
+			req = mux.SetURLVars(req, map[string]string{"id": tt.paymentMethodID})
+
+			handler.AttachPaymentMethod(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+
+			if tt.expectedBody != "" && !strings.Contains(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("Expected body to contain %q, got %s", tt.expectedBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestStripeHandler_SetDefaultPaymentMethod(t *testing.T) {
+	tests := []struct {
+		name           string
+		customerID     string
+		requestBody    string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "set default",
+			customerID:     "cus_123",
+			requestBody:    `{"payment_method_id":"pm_123"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing payment method",
+			customerID:     "cus_123",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty customer ID",
+			customerID:     "",
+			requestBody:    `{"payment_method_id":"pm_123"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			customerID:     "cus_123",
+			requestBody:    `{"payment_method_id":"pm_123"}`,
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "update error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("POST", "/customers/"+tt.customerID+"/default-payment-method", strings.NewReader(tt.requestBody))
+			rr := httptest.NewRecorder()
+
+			req = mux.SetURLVars(req, map[string]string{"id": tt.customerID})
+
+			handler.SetDefaultPaymentMethod(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+
+	"stripe-service/internal/models"
+)
+
+// Customer portal handlers
+
+// CreatePortalSession handles requests to open the customer portal for a customer
+func (h *StripeHandler) CreatePortalSession(w http.ResponseWriter, r *http.Request) {
+	customerID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.CreatePortalSessionRequest
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	session, err := h.stripeService.CreatePortalSession(r.Context(), customerID, &req)
+	if err != nil {
+		h.handleServiceError(w, err, "create portal session", map[string]interface{}{
+			"customer_id":      customerID,
+			"configuration_id": req.ConfigurationID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, session)
+}
+
+// CreatePortalConfiguration handles portal configuration creation requests
+func (h *StripeHandler) CreatePortalConfiguration(w http.ResponseWriter, r *http.Request) {
+	var req models.CreatePortalConfigurationRequest
+
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	config, err := h.stripeService.CreatePortalConfiguration(r.Context(), &req)
+	if err != nil {
+		h.handleServiceError(w, err, "create portal configuration", nil)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, config)
+}
+
+// GetPortalConfiguration handles portal configuration retrieval requests
+func (h *StripeHandler) GetPortalConfiguration(w http.ResponseWriter, r *http.Request) {
+	configurationID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	config, err := h.stripeService.GetPortalConfiguration(r.Context(), configurationID)
+	if err != nil {
+		h.handleServiceError(w, err, "get portal configuration", map[string]interface{}{
+			"configuration_id": configurationID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, config)
+}
+
+// UpdatePortalConfiguration handles partial portal configuration update requests
+func (h *StripeHandler) UpdatePortalConfiguration(w http.ResponseWriter, r *http.Request) {
+	configurationID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdatePortalConfigurationRequest
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	config, err := h.stripeService.UpdatePortalConfiguration(r.Context(), configurationID, &req)
+	if err != nil {
+		h.handleServiceError(w, err, "update portal configuration", map[string]interface{}{
+			"configuration_id": configurationID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, config)
+}
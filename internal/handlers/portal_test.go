@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"stripe-service/internal/models"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+func (m *MockStripeService) CreatePortalSession(ctx context.Context, customerID string, req *models.CreatePortalSessionRequest) (*models.PortalSession, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.PortalSession{
+		ID:              "bps_test123",
+		URL:             "https://billing.stripe.com/p/session/test_123",
+		CustomerID:      customerID,
+		ReturnURL:       req.ReturnURL,
+		ConfigurationID: req.ConfigurationID,
+		CreatedAt:       time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) CreatePortalConfiguration(ctx context.Context, req *models.CreatePortalConfigurationRequest) (*models.PortalConfiguration, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.PortalConfiguration{
+		ID:        "bpc_test123",
+		Active:    true,
+		Headline:  req.Headline,
+		Features:  req.Features,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) GetPortalConfiguration(ctx context.Context, configurationID string) (*models.PortalConfiguration, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.PortalConfiguration{
+		ID:        configurationID,
+		Active:    true,
+		IsDefault: true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) UpdatePortalConfiguration(ctx context.Context, configurationID string, req *models.UpdatePortalConfigurationRequest) (*models.PortalConfiguration, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	config := &models.PortalConfiguration{
+		ID:        configurationID,
+		Active:    true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if req.Active != nil {
+		config.Active = *req.Active
+	}
+	if req.Features != nil {
+		config.Features = *req.Features
+	}
+	return config, nil
+}
+
+func TestStripeHandler_CreatePortalSession(t *testing.T) {
+	tests := []struct {
+		name           string
+		customerID     string
+		requestBody    string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "with configuration",
+			customerID:     "cus_123",
+			requestBody:    `{"return_url":"https://example.com/account","configuration_id":"bpc_123"}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing return url",
+			customerID:     "cus_123",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty customer ID",
+			customerID:     "",
+			requestBody:    `{"return_url":"https://example.com/account"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			customerID:     "cus_123",
+			requestBody:    `{"return_url":"https://example.com/account"}`,
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "portal error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("POST", "/customers/"+tt.customerID+"/portal-session", strings.NewReader(tt.requestBody))
+			rr := httptest.NewRecorder()
+
+			req = mux.SetURLVars(req, map[string]string{"id": tt.customerID})
+
+			handler.CreatePortalSession(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_CreatePortalConfiguration(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "cancel and plan switch enabled",
+			requestBody:    `{"features":{"subscription_cancel":{"enabled":true,"mode":"at_period_end"},"subscription_update":{"enabled":true,"default_allowed_updates":["price"],"products":[{"product_id":"prod_123","price_ids":["price_1","price_2"]}]}}}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid cancel mode",
+			requestBody:    `{"features":{"subscription_cancel":{"enabled":true,"mode":"later"}}}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			requestBody:    `{"features":{"invoice_history":true}}`,
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "portal error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("POST", "/portal/configurations", strings.NewReader(tt.requestBody))
+			rr := httptest.NewRecorder()
+
+			handler.CreatePortalConfiguration(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_GetAndUpdatePortalConfiguration(t *testing.T) {
+	tests := []struct {
+		name            string
+		configurationID string
+		shouldError     bool
+		expectedStatus  int
+	}{
+		{
+			name:            "valid configuration ID",
+			configurationID: "bpc_123",
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name:            "empty configuration ID",
+			configurationID: "",
+			expectedStatus:  http.StatusBadRequest,
+		},
+		{
+			name:            "service error",
+			configurationID: "bpc_123",
+			shouldError:     true,
+			expectedStatus:  http.StatusInternalServerError,
+		},
+	}
+
+	handlers := map[string]func(h *StripeHandler) http.HandlerFunc{
+		"get":    func(h *StripeHandler) http.HandlerFunc { return h.GetPortalConfiguration },
+		"update": func(h *StripeHandler) http.HandlerFunc { return h.UpdatePortalConfiguration },
+	}
+
+	for op, handlerFunc := range handlers {
+		for _, tt := range tests {
+			t.Run(op+" "+tt.name, func(t *testing.T) {
+				mockService := &MockStripeService{
+					shouldError: tt.shouldError,
+					errorMsg:    "portal error",
+				}
+				handler := &StripeHandler{
+					stripeService: mockService,
+					validator:     validator.New(),
+				}
+
+				req := httptest.NewRequest("PATCH", "/portal/configurations/"+tt.configurationID, strings.NewReader(`{"active":false}`))
+				rr := httptest.NewRecorder()
+
+				req = mux.SetURLVars(req, map[string]string{"id": tt.configurationID})
+
+				handlerFunc(handler)(rr, req)
+
+				if status := rr.Code; status != tt.expectedStatus {
+					t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"stripe-service/internal/models"
+)
+
+// Refund handlers
+
+// CreateRefund handles refund creation requests
+func (h *StripeHandler) CreateRefund(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateRefundRequest
+
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	refund, err := h.stripeService.CreateRefund(r.Context(), &req)
+	if err != nil {
+		h.handleServiceError(w, err, "create refund", map[string]interface{}{
+			"payment_intent_id": req.PaymentIntentID,
+			"amount":            req.Amount,
+			"reason":            req.Reason,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, refund)
+}
+
+// GetRefund handles refund retrieval requests
+func (h *StripeHandler) GetRefund(w http.ResponseWriter, r *http.Request) {
+	refundID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	refund, err := h.stripeService.GetRefund(r.Context(), refundID)
+	if err != nil {
+		h.handleServiceError(w, err, "get refund", map[string]interface{}{
+			"refund_id": refundID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, refund)
+}
+
+// ListRefunds handles refund listing requests
+func (h *StripeHandler) ListRefunds(w http.ResponseWriter, r *http.Request) {
+	req := &models.ListRefundsRequest{}
+	query := r.URL.Query()
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if limit, err := strconv.ParseInt(limitStr, 10, 64); err == nil {
+			req.Limit = limit
+		}
+	}
+
+	req.Cursor = query.Get("cursor")
+	req.PaymentIntentID = query.Get("payment_intent")
+
+	refunds, err := h.stripeService.ListRefunds(r.Context(), req)
+	if err != nil {
+		h.handleServiceError(w, err, "list refunds", map[string]interface{}{
+			"limit":             req.Limit,
+			"cursor":            req.Cursor,
+			"payment_intent_id": req.PaymentIntentID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, refunds)
+}
+
+// CancelRefund handles refund cancellation requests
+func (h *StripeHandler) CancelRefund(w http.ResponseWriter, r *http.Request) {
+	refundID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	refund, err := h.stripeService.CancelRefund(r.Context(), refundID)
+	if err != nil {
+		h.handleServiceError(w, err, "cancel refund", map[string]interface{}{
+			"refund_id": refundID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, refund)
+}
@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"stripe-service/internal/models"
+	"stripe-service/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+func (m *MockStripeService) CreateRefund(ctx context.Context, req *models.CreateRefundRequest) (*models.Refund, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	if req.Amount > 1000 {
+		return nil, &service.ValidationError{Field: "amount", Message: "exceeds the remaining refundable amount of 1000"}
+	}
+	amount := req.Amount
+	if amount == 0 {
+		amount = 1000
+	}
+	return &models.Refund{
+		ID:              "re_test123",
+		Amount:          amount,
+		Currency:        "usd",
+		Status:          "succeeded",
+		Reason:          req.Reason,
+		PaymentIntentID: req.PaymentIntentID,
+		Metadata:        req.Metadata,
+		CreatedAt:       time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) GetRefund(ctx context.Context, refundID string) (*models.Refund, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.Refund{
+		ID:        refundID,
+		Amount:    1000,
+		Currency:  "usd",
+		Status:    "succeeded",
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) ListRefunds(ctx context.Context, req *models.ListRefundsRequest) (*models.ListRefundsResponse, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.ListRefundsResponse{
+		Refunds: []models.Refund{
+			{ID: "re_test1", Amount: 500, Currency: "usd", Status: "succeeded", PaymentIntentID: req.PaymentIntentID},
+		},
+		HasMore: false,
+	}, nil
+}
+
+func (m *MockStripeService) CancelRefund(ctx context.Context, refundID string) (*models.Refund, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.Refund{
+		ID:        refundID,
+		Amount:    1000,
+		Currency:  "usd",
+		Status:    "canceled",
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func TestStripeHandler_CreateRefund(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		shouldError    bool
+		errorMsg       string
+		expectedStatus int
+	}{
+		{
+			name: "full refund",
+			requestBody: models.CreateRefundRequest{
+				PaymentIntentID: "pi_123",
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "partial refund with reason and metadata",
+			requestBody: models.CreateRefundRequest{
+				PaymentIntentID: "pi_123",
+				Amount:          500,
+				Reason:          "requested_by_customer",
+				Metadata:        map[string]string{"ticket": "T-42"},
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "amount exceeds remaining captured amount",
+			requestBody: models.CreateRefundRequest{
+				PaymentIntentID: "pi_123",
+				Amount:          5000,
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing payment intent",
+			requestBody:    models.CreateRefundRequest{Amount: 500},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid reason",
+			requestBody: models.CreateRefundRequest{
+				PaymentIntentID: "pi_123",
+				Reason:          "changed_mind",
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			requestBody: models.CreateRefundRequest{
+				PaymentIntentID: "pi_123",
+			},
+			shouldError:    true,
+			errorMsg:       "refund error",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    tt.errorMsg,
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			var body bytes.Buffer
+			if tt.requestBody != "invalid json" {
+				json.NewEncoder(&body).Encode(tt.requestBody)
+			} else {
+				body.WriteString("invalid json")
+			}
+
+			req := httptest.NewRequest("POST", "/refunds", &body)
+			rr := httptest.NewRecorder()
+
+			handler.CreateRefund(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_GetAndCancelRefund(t *testing.T) {
+	tests := []struct {
+		name           string
+		refundID       string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "valid refund ID",
+			refundID:       "re_123",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "empty refund ID",
+			refundID:       "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			refundID:       "re_123",
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	handlers := map[string]func(h *StripeHandler) http.HandlerFunc{
+		"get":    func(h *StripeHandler) http.HandlerFunc { return h.GetRefund },
+		"cancel": func(h *StripeHandler) http.HandlerFunc { return h.CancelRefund },
+	}
+
+	for op, handlerFunc := range handlers {
+		for _, tt := range tests {
+			t.Run(op+" "+tt.name, func(t *testing.T) {
+				mockService := &MockStripeService{
+					shouldError: tt.shouldError,
+					errorMsg:    "refund error",
+				}
+				handler := &StripeHandler{
+					stripeService: mockService,
+					validator:     validator.New(),
+				}
+
+				req := httptest.NewRequest("GET", "/refunds/"+tt.refundID, nil)
+				rr := httptest.NewRecorder()
+
+				req = mux.SetURLVars(req, map[string]string{"id": tt.refundID})
+
+				handlerFunc(handler)(rr, req)
+
+				if status := rr.Code; status != tt.expectedStatus {
+					t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+				}
+			})
+		}
+	}
+}
+
+func TestStripeHandler_ListRefunds(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "default list",
+			queryParams:    "",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "filter by payment intent",
+			queryParams:    "?payment_intent=pi_123&limit=5&cursor=re_1",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "service error",
+			queryParams:    "",
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "list error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("GET", "/refunds"+tt.queryParams, nil)
+			rr := httptest.NewRecorder()
+
+			handler.ListRefunds(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+
+	"stripe-service/internal/models"
+)
+
+// Setup intent handlers
+
+// CreateSetupIntent handles setup intent creation requests
+func (h *StripeHandler) CreateSetupIntent(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateSetupIntentRequest
+
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	setupIntent, err := h.stripeService.CreateSetupIntent(r.Context(), &req)
+	if err != nil {
+		h.handleServiceError(w, err, "create setup intent", map[string]interface{}{
+			"customer_id": req.CustomerID,
+			"usage":       req.Usage,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, setupIntent)
+}
+
+// GetSetupIntent handles setup intent retrieval requests
+func (h *StripeHandler) GetSetupIntent(w http.ResponseWriter, r *http.Request) {
+	setupIntentID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	setupIntent, err := h.stripeService.GetSetupIntent(r.Context(), setupIntentID)
+	if err != nil {
+		h.handleServiceError(w, err, "get setup intent", map[string]interface{}{
+			"setup_intent_id": setupIntentID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, setupIntent)
+}
+
+// ConfirmSetupIntent handles setup intent confirmation requests
+func (h *StripeHandler) ConfirmSetupIntent(w http.ResponseWriter, r *http.Request) {
+	setupIntentID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.ConfirmSetupIntentRequest
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	setupIntent, err := h.stripeService.ConfirmSetupIntent(r.Context(), setupIntentID, &req)
+	if err != nil {
+		h.handleServiceError(w, err, "confirm setup intent", map[string]interface{}{
+			"setup_intent_id":   setupIntentID,
+			"payment_method_id": req.PaymentMethodID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, setupIntent)
+}
+
+// CancelSetupIntent handles setup intent cancellation requests
+func (h *StripeHandler) CancelSetupIntent(w http.ResponseWriter, r *http.Request) {
+	setupIntentID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.CancelSetupIntentRequest
+	if !h.parseOptionalJSON(w, r, &req) {
+		return
+	}
+
+	setupIntent, err := h.stripeService.CancelSetupIntent(r.Context(), setupIntentID, &req)
+	if err != nil {
+		h.handleServiceError(w, err, "cancel setup intent", map[string]interface{}{
+			"setup_intent_id":     setupIntentID,
+			"cancellation_reason": req.CancellationReason,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, setupIntent)
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"stripe-service/internal/models"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+func (m *MockStripeService) CreateSetupIntent(ctx context.Context, req *models.CreateSetupIntentRequest) (*models.SetupIntent, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	usage := req.Usage
+	if usage == "" {
+		usage = "off_session"
+	}
+	return &models.SetupIntent{
+		ID:           "seti_test123",
+		Status:       "requires_payment_method",
+		Usage:        usage,
+		CustomerID:   req.CustomerID,
+		ClientSecret: "seti_test123_secret_456",
+		CreatedAt:    time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) GetSetupIntent(ctx context.Context, setupIntentID string) (*models.SetupIntent, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.SetupIntent{
+		ID:        setupIntentID,
+		Status:    "requires_payment_method",
+		Usage:     "off_session",
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) ConfirmSetupIntent(ctx context.Context, setupIntentID string, req *models.ConfirmSetupIntentRequest) (*models.SetupIntent, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.SetupIntent{
+		ID:              setupIntentID,
+		Status:          "succeeded",
+		Usage:           "off_session",
+		PaymentMethodID: req.PaymentMethodID,
+		CreatedAt:       time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) CancelSetupIntent(ctx context.Context, setupIntentID string, req *models.CancelSetupIntentRequest) (*models.SetupIntent, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.SetupIntent{
+		ID:                 setupIntentID,
+		Status:             "canceled",
+		Usage:              "off_session",
+		CancellationReason: req.CancellationReason,
+		CreatedAt:          time.Now(),
+	}, nil
+}
+
+func TestStripeHandler_CreateSetupIntent(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "off session setup",
+			requestBody:    `{"customer_id":"cus_123","usage":"off_session","payment_method_types":["card"]}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "empty request uses defaults",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid usage",
+			requestBody:    `{"usage":"sometimes"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			requestBody:    `{"customer_id":"cus_123"}`,
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "setup intent error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("POST", "/setup-intents", strings.NewReader(tt.requestBody))
+			rr := httptest.NewRecorder()
+
+			handler.CreateSetupIntent(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_SetupIntentActions(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        func(h *StripeHandler) http.HandlerFunc
+		setupIntentID  string
+		requestBody    string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "get setup intent",
+			handler:        func(h *StripeHandler) http.HandlerFunc { return h.GetSetupIntent },
+			setupIntentID:  "seti_123",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "get with empty ID",
+			handler:        func(h *StripeHandler) http.HandlerFunc { return h.GetSetupIntent },
+			setupIntentID:  "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "confirm setup intent",
+			handler:        func(h *StripeHandler) http.HandlerFunc { return h.ConfirmSetupIntent },
+			setupIntentID:  "seti_123",
+			requestBody:    `{"payment_method_id":"pm_card_visa"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "confirm with invalid JSON",
+			handler:        func(h *StripeHandler) http.HandlerFunc { return h.ConfirmSetupIntent },
+			setupIntentID:  "seti_123",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "confirm service error",
+			handler:        func(h *StripeHandler) http.HandlerFunc { return h.ConfirmSetupIntent },
+			setupIntentID:  "seti_123",
+			requestBody:    `{}`,
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "cancel without body",
+			handler:        func(h *StripeHandler) http.HandlerFunc { return h.CancelSetupIntent },
+			setupIntentID:  "seti_123",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "cancel with invalid reason",
+			handler:        func(h *StripeHandler) http.HandlerFunc { return h.CancelSetupIntent },
+			setupIntentID:  "seti_123",
+			requestBody:    `{"cancellation_reason":"fraudulent"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "setup intent error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("POST", "/setup-intents/"+tt.setupIntentID, strings.NewReader(tt.requestBody))
+			rr := httptest.NewRecorder()
+
+			req = mux.SetURLVars(req, map[string]string{"id": tt.setupIntentID})
+
+			tt.handler(handler)(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
@@ -2,10 +2,14 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"stripe-service/internal/models"
 	"stripe-service/internal/service"
@@ -32,6 +36,13 @@ func NewStripeHandler(stripeService service.StripeServiceInterface) *StripeHandl
 
 // handleServiceError provides consistent error handling for service operations
 func (h *StripeHandler) handleServiceError(w http.ResponseWriter, err error, operation string, details map[string]interface{}) {
+	// Requests rejected by service-side validation are the client's fault
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("Validation error: %v", validationErr))
+		return
+	}
+
 	// Structured logging with context
 	logFields := map[string]interface{}{
 		"operation": operation,
@@ -62,6 +73,21 @@ func (h *StripeHandler) parseAndValidateJSON(w http.ResponseWriter, r *http.Requ
 	return true
 }
 
+// parseOptionalJSON parses and validates a request body that may be omitted entirely
+func (h *StripeHandler) parseOptionalJSON(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil && !errors.Is(err, io.EOF) {
+		h.writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return false
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("Validation error: %v", err))
+		return false
+	}
+
+	return true
+}
+
 // extractPathParameter extracts and validates path parameters
 func (h *StripeHandler) extractPathParameter(w http.ResponseWriter, r *http.Request, paramName string) (string, bool) {
 	vars := mux.Vars(r)
@@ -75,6 +101,61 @@ func (h *StripeHandler) extractPathParameter(w http.ResponseWriter, r *http.Requ
 	return value, true
 }
 
+// parseTimestampQuery parses an optional Unix timestamp query parameter
+func (h *StripeHandler) parseTimestampQuery(w http.ResponseWriter, query url.Values, paramName string) (int64, bool) {
+	value := query.Get(paramName)
+	if value == "" {
+		return 0, true
+	}
+
+	timestamp, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || timestamp < 0 {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("%s must be a Unix timestamp", paramName))
+		return 0, false
+	}
+
+	return timestamp, true
+}
+
+// parseBoolQuery parses an optional boolean query parameter, returning nil when it is absent
+func (h *StripeHandler) parseBoolQuery(w http.ResponseWriter, query url.Values, paramName string) (*bool, bool) {
+	value := query.Get(paramName)
+	if value == "" {
+		return nil, true
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("%s must be true or false", paramName))
+		return nil, false
+	}
+
+	return &parsed, true
+}
+
+// parseMetadataQuery collects metadata[key]=value query parameters
+func parseMetadataQuery(query url.Values) map[string]string {
+	var metadata map[string]string
+
+	for param, values := range query {
+		if !strings.HasPrefix(param, "metadata[") || !strings.HasSuffix(param, "]") || len(values) == 0 {
+			continue
+		}
+
+		key := strings.TrimSuffix(strings.TrimPrefix(param, "metadata["), "]")
+		if key == "" {
+			continue
+		}
+
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+		metadata[key] = values[0]
+	}
+
+	return metadata
+}
+
 // HealthCheck handles health check requests
 func (h *StripeHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	response := map[string]string{
@@ -125,26 +206,50 @@ func (h *StripeHandler) GetCustomer(w http.ResponseWriter, r *http.Request) {
 	h.writeJSON(w, http.StatusOK, customer)
 }
 
-// ListCustomers handles customer listing requests
+// ListCustomers handles customer listing and search requests
 func (h *StripeHandler) ListCustomers(w http.ResponseWriter, r *http.Request) {
 	req := &models.ListCustomersRequest{}
+	query := r.URL.Query()
 
 	// Parse optional query parameters
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+	if limitStr := query.Get("limit"); limitStr != "" {
 		if limit, err := strconv.ParseInt(limitStr, 10, 64); err == nil {
 			req.Limit = limit
 		}
 	}
 
-	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
-		req.Cursor = cursor
+	req.Cursor = query.Get("cursor")
+	req.EndingBefore = query.Get("ending_before")
+	req.Email = query.Get("email")
+	req.Query = query.Get("query")
+	req.Page = query.Get("page")
+	req.Metadata = parseMetadataQuery(query)
+
+	var ok bool
+	if req.CreatedGte, ok = h.parseTimestampQuery(w, query, "created_gte"); !ok {
+		return
+	}
+	if req.CreatedLte, ok = h.parseTimestampQuery(w, query, "created_lte"); !ok {
+		return
+	}
+
+	if req.Cursor != "" && req.EndingBefore != "" {
+		h.writeError(w, http.StatusBadRequest, "cursor and ending_before cannot be used together")
+		return
+	}
+
+	if (req.Query != "" || req.Page != "" || len(req.Metadata) > 0) && (req.Cursor != "" || req.EndingBefore != "") {
+		h.writeError(w, http.StatusBadRequest, "Search results are paged with page, not cursor or ending_before")
+		return
 	}
 
 	customers, err := h.stripeService.ListCustomers(r.Context(), req)
 	if err != nil {
 		h.handleServiceError(w, err, "list customers", map[string]interface{}{
-			"limit":  req.Limit,
-			"cursor": req.Cursor,
+			"limit":         req.Limit,
+			"cursor":        req.Cursor,
+			"ending_before": req.EndingBefore,
+			"query":         req.Query,
 		})
 		return
 	}
@@ -152,6 +257,47 @@ func (h *StripeHandler) ListCustomers(w http.ResponseWriter, r *http.Request) {
 	h.writeJSON(w, http.StatusOK, customers)
 }
 
+// UpdateCustomer handles partial customer update requests
+func (h *StripeHandler) UpdateCustomer(w http.ResponseWriter, r *http.Request) {
+	customerID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateCustomerRequest
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	customer, err := h.stripeService.UpdateCustomer(r.Context(), customerID, &req)
+	if err != nil {
+		h.handleServiceError(w, err, "update customer", map[string]interface{}{
+			"customer_id": customerID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, customer)
+}
+
+// DeleteCustomer handles customer deletion requests
+func (h *StripeHandler) DeleteCustomer(w http.ResponseWriter, r *http.Request) {
+	customerID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	deleted, err := h.stripeService.DeleteCustomer(r.Context(), customerID)
+	if err != nil {
+		h.handleServiceError(w, err, "delete customer", map[string]interface{}{
+			"customer_id": customerID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, deleted)
+}
+
 // Payment handlers
 
 // CreatePaymentIntent handles payment intent creation requests
@@ -199,6 +345,143 @@ func (h *StripeHandler) ConfirmPaymentIntent(w http.ResponseWriter, r *http.Requ
 	h.writeJSON(w, http.StatusOK, paymentIntent)
 }
 
+// GetPaymentIntent handles payment intent retrieval requests
+func (h *StripeHandler) GetPaymentIntent(w http.ResponseWriter, r *http.Request) {
+	paymentIntentID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	paymentIntent, err := h.stripeService.GetPaymentIntent(r.Context(), paymentIntentID)
+	if err != nil {
+		h.handleServiceError(w, err, "get payment intent", map[string]interface{}{
+			"payment_intent_id": paymentIntentID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, paymentIntent)
+}
+
+// ListPaymentIntents handles payment intent listing requests
+func (h *StripeHandler) ListPaymentIntents(w http.ResponseWriter, r *http.Request) {
+	req := &models.ListPaymentIntentsRequest{}
+	query := r.URL.Query()
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if limit, err := strconv.ParseInt(limitStr, 10, 64); err == nil {
+			req.Limit = limit
+		}
+	}
+
+	req.Cursor = query.Get("cursor")
+	req.CustomerID = query.Get("customer")
+	req.Status = query.Get("status")
+	req.Page = query.Get("page")
+
+	var ok bool
+	if req.CreatedGte, ok = h.parseTimestampQuery(w, query, "created_gte"); !ok {
+		return
+	}
+	if req.CreatedLte, ok = h.parseTimestampQuery(w, query, "created_lte"); !ok {
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("Validation error: %v", err))
+		return
+	}
+
+	if (req.Status != "" || req.Page != "") && req.Cursor != "" {
+		h.writeError(w, http.StatusBadRequest, "Results filtered by status are paged with page, not cursor")
+		return
+	}
+
+	paymentIntents, err := h.stripeService.ListPaymentIntents(r.Context(), req)
+	if err != nil {
+		h.handleServiceError(w, err, "list payment intents", map[string]interface{}{
+			"limit":       req.Limit,
+			"cursor":      req.Cursor,
+			"customer_id": req.CustomerID,
+			"status":      req.Status,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, paymentIntents)
+}
+
+// UpdatePaymentIntent handles partial payment intent update requests
+func (h *StripeHandler) UpdatePaymentIntent(w http.ResponseWriter, r *http.Request) {
+	paymentIntentID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdatePaymentIntentRequest
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	paymentIntent, err := h.stripeService.UpdatePaymentIntent(r.Context(), paymentIntentID, &req)
+	if err != nil {
+		h.handleServiceError(w, err, "update payment intent", map[string]interface{}{
+			"payment_intent_id": paymentIntentID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, paymentIntent)
+}
+
+// CancelPaymentIntent handles payment intent cancellation requests
+func (h *StripeHandler) CancelPaymentIntent(w http.ResponseWriter, r *http.Request) {
+	paymentIntentID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.CancelPaymentIntentRequest
+	if !h.parseOptionalJSON(w, r, &req) {
+		return
+	}
+
+	paymentIntent, err := h.stripeService.CancelPaymentIntent(r.Context(), paymentIntentID, &req)
+	if err != nil {
+		h.handleServiceError(w, err, "cancel payment intent", map[string]interface{}{
+			"payment_intent_id":   paymentIntentID,
+			"cancellation_reason": req.CancellationReason,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, paymentIntent)
+}
+
+// CapturePaymentIntent handles payment intent capture requests
+func (h *StripeHandler) CapturePaymentIntent(w http.ResponseWriter, r *http.Request) {
+	paymentIntentID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.CapturePaymentIntentRequest
+	if !h.parseOptionalJSON(w, r, &req) {
+		return
+	}
+
+	paymentIntent, err := h.stripeService.CapturePaymentIntent(r.Context(), paymentIntentID, &req)
+	if err != nil {
+		h.handleServiceError(w, err, "capture payment intent", map[string]interface{}{
+			"payment_intent_id": paymentIntentID,
+			"amount_to_capture": req.AmountToCapture,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, paymentIntent)
+}
+
 // Product handlers
 
 // CreateProduct handles product creation requests
@@ -221,79 +504,610 @@ func (h *StripeHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 	h.writeJSON(w, http.StatusCreated, product)
 }
 
-// CreatePrice handles price creation requests
-func (h *StripeHandler) CreatePrice(w http.ResponseWriter, r *http.Request) {
-	var req models.CreatePriceRequest
-
-	if !h.parseAndValidateJSON(w, r, &req) {
+// GetProduct handles product retrieval requests
+func (h *StripeHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
 		return
 	}
 
-	price, err := h.stripeService.CreatePrice(r.Context(), &req)
+	product, err := h.stripeService.GetProduct(r.Context(), productID)
 	if err != nil {
-		h.handleServiceError(w, err, "create price", map[string]interface{}{
-			"product_id":  req.ProductID,
-			"unit_amount": req.UnitAmount,
-			"currency":    req.Currency,
+		h.handleServiceError(w, err, "get product", map[string]interface{}{
+			"product_id": productID,
 		})
 		return
 	}
 
-	h.writeJSON(w, http.StatusCreated, price)
+	h.writeJSON(w, http.StatusOK, product)
 }
 
-// Subscription handlers
+// ListProducts handles product listing requests
+func (h *StripeHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
+	req := &models.ListProductsRequest{}
+	query := r.URL.Query()
 
-// CreateSubscription handles subscription creation requests
-func (h *StripeHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
-	var req models.CreateSubscriptionRequest
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if limit, err := strconv.ParseInt(limitStr, 10, 64); err == nil {
+			req.Limit = limit
+		}
+	}
 
-	if !h.parseAndValidateJSON(w, r, &req) {
+	req.Cursor = query.Get("cursor")
+
+	active, ok := h.parseBoolQuery(w, query, "active")
+	if !ok {
 		return
 	}
+	req.Active = active
 
-	subscription, err := h.stripeService.CreateSubscription(r.Context(), &req)
+	products, err := h.stripeService.ListProducts(r.Context(), req)
 	if err != nil {
-		h.handleServiceError(w, err, "create subscription", map[string]interface{}{
-			"customer_id": req.CustomerID,
-			"price_id":    req.PriceID,
+		h.handleServiceError(w, err, "list products", map[string]interface{}{
+			"limit":  req.Limit,
+			"cursor": req.Cursor,
 		})
 		return
 	}
 
-	h.writeJSON(w, http.StatusCreated, subscription)
+	h.writeJSON(w, http.StatusOK, products)
 }
 
-// CancelSubscription handles subscription cancellation requests
-func (h *StripeHandler) CancelSubscription(w http.ResponseWriter, r *http.Request) {
-	subscriptionID, ok := h.extractPathParameter(w, r, "id")
+// UpdateProduct handles partial product update requests
+func (h *StripeHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.extractPathParameter(w, r, "id")
 	if !ok {
 		return
 	}
 
-	subscription, err := h.stripeService.CancelSubscription(r.Context(), subscriptionID)
+	var req models.UpdateProductRequest
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	product, err := h.stripeService.UpdateProduct(r.Context(), productID, &req)
 	if err != nil {
-		h.handleServiceError(w, err, "cancel subscription", map[string]interface{}{
-			"subscription_id": subscriptionID,
+		h.handleServiceError(w, err, "update product", map[string]interface{}{
+			"product_id": productID,
 		})
 		return
 	}
 
-	h.writeJSON(w, http.StatusOK, subscription)
+	h.writeJSON(w, http.StatusOK, product)
 }
 
-// Helper methods for response handling
+// ArchiveProduct handles product archive requests
+func (h *StripeHandler) ArchiveProduct(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
 
-func (h *StripeHandler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
+	product, err := h.stripeService.ArchiveProduct(r.Context(), productID)
+	if err != nil {
+		h.handleServiceError(w, err, "archive product", map[string]interface{}{
+			"product_id": productID,
+		})
+		return
+	}
 
-	if err := json.NewEncoder(w).Encode(data); err != nil {
-		log.Printf("Error encoding JSON response: %v", err)
+	h.writeJSON(w, http.StatusOK, product)
+}
+
+// DeleteProduct handles product deletion requests
+func (h *StripeHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
+	productID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
 	}
+
+	deleted, err := h.stripeService.DeleteProduct(r.Context(), productID)
+	if err != nil {
+		h.handleServiceError(w, err, "delete product", map[string]interface{}{
+			"product_id": productID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, deleted)
 }
 
-func (h *StripeHandler) writeError(w http.ResponseWriter, status int, message string) {
+// CreatePrice handles price creation requests
+func (h *StripeHandler) CreatePrice(w http.ResponseWriter, r *http.Request) {
+	var req models.CreatePriceRequest
+
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	price, err := h.stripeService.CreatePrice(r.Context(), &req)
+	if err != nil {
+		h.handleServiceError(w, err, "create price", map[string]interface{}{
+			"product_id":  req.ProductID,
+			"unit_amount": req.UnitAmount,
+			"currency":    req.Currency,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, price)
+}
+
+// GetPrice handles price retrieval requests
+func (h *StripeHandler) GetPrice(w http.ResponseWriter, r *http.Request) {
+	priceID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	price, err := h.stripeService.GetPrice(r.Context(), priceID)
+	if err != nil {
+		h.handleServiceError(w, err, "get price", map[string]interface{}{
+			"price_id": priceID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, price)
+}
+
+// ListPrices handles price listing and lookup key resolution requests
+func (h *StripeHandler) ListPrices(w http.ResponseWriter, r *http.Request) {
+	req := &models.ListPricesRequest{}
+	query := r.URL.Query()
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if limit, err := strconv.ParseInt(limitStr, 10, 64); err == nil {
+			req.Limit = limit
+		}
+	}
+
+	req.Cursor = query.Get("cursor")
+	req.ProductID = query.Get("product")
+	req.Type = query.Get("type")
+	req.Currency = query.Get("currency")
+	req.LookupKeys = query["lookup_key"]
+
+	active, ok := h.parseBoolQuery(w, query, "active")
+	if !ok {
+		return
+	}
+	req.Active = active
+
+	if err := h.validator.Struct(req); err != nil {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("Validation error: %v", err))
+		return
+	}
+
+	prices, err := h.stripeService.ListPrices(r.Context(), req)
+	if err != nil {
+		h.handleServiceError(w, err, "list prices", map[string]interface{}{
+			"limit":       req.Limit,
+			"cursor":      req.Cursor,
+			"product_id":  req.ProductID,
+			"lookup_keys": req.LookupKeys,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, prices)
+}
+
+// UpdatePrice handles partial price update requests
+func (h *StripeHandler) UpdatePrice(w http.ResponseWriter, r *http.Request) {
+	priceID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdatePriceRequest
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	price, err := h.stripeService.UpdatePrice(r.Context(), priceID, &req)
+	if err != nil {
+		h.handleServiceError(w, err, "update price", map[string]interface{}{
+			"price_id": priceID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, price)
+}
+
+// Subscription handlers
+
+// CreateSubscription handles subscription creation requests
+func (h *StripeHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateSubscriptionRequest
+
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	subscription, err := h.stripeService.CreateSubscription(r.Context(), &req)
+	if err != nil {
+		h.handleServiceError(w, err, "create subscription", map[string]interface{}{
+			"customer_id": req.CustomerID,
+			"price_id":    req.PriceID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, subscription)
+}
+
+// CancelSubscription handles subscription cancellation requests. The cancellation
+// mode and its options are read from the mode, cancel_at, prorate and invoice_now
+// query parameters.
+func (h *StripeHandler) CancelSubscription(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	req := &models.CancelSubscriptionRequest{}
+	query := r.URL.Query()
+	req.Mode = query.Get("mode")
+
+	if req.CancelAt, ok = h.parseTimestampQuery(w, query, "cancel_at"); !ok {
+		return
+	}
+	if req.Prorate, ok = h.parseBoolQuery(w, query, "prorate"); !ok {
+		return
+	}
+	if req.InvoiceNow, ok = h.parseBoolQuery(w, query, "invoice_now"); !ok {
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("Validation error: %v", err))
+		return
+	}
+
+	subscription, err := h.stripeService.CancelSubscription(r.Context(), subscriptionID, req)
+	if err != nil {
+		h.handleServiceError(w, err, "cancel subscription", map[string]interface{}{
+			"subscription_id": subscriptionID,
+			"mode":            req.Mode,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, subscription)
+}
+
+// UndoSubscriptionCancellation handles requests to clear a scheduled subscription cancellation
+func (h *StripeHandler) UndoSubscriptionCancellation(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	subscription, err := h.stripeService.UndoSubscriptionCancellation(r.Context(), subscriptionID)
+	if err != nil {
+		h.handleServiceError(w, err, "undo subscription cancellation", map[string]interface{}{
+			"subscription_id": subscriptionID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, subscription)
+}
+
+// GetSubscription handles subscription retrieval requests
+func (h *StripeHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	subscription, err := h.stripeService.GetSubscription(r.Context(), subscriptionID)
+	if err != nil {
+		h.handleServiceError(w, err, "get subscription", map[string]interface{}{
+			"subscription_id": subscriptionID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, subscription)
+}
+
+// ListSubscriptions handles subscription listing requests
+func (h *StripeHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	req := &models.ListSubscriptionsRequest{}
+	query := r.URL.Query()
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if limit, err := strconv.ParseInt(limitStr, 10, 64); err == nil {
+			req.Limit = limit
+		}
+	}
+
+	req.Cursor = query.Get("cursor")
+	req.CustomerID = query.Get("customer")
+	req.Status = query.Get("status")
+	req.PriceID = query.Get("price")
+
+	if err := h.validator.Struct(req); err != nil {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("Validation error: %v", err))
+		return
+	}
+
+	subscriptions, err := h.stripeService.ListSubscriptions(r.Context(), req)
+	if err != nil {
+		h.handleServiceError(w, err, "list subscriptions", map[string]interface{}{
+			"limit":       req.Limit,
+			"cursor":      req.Cursor,
+			"customer_id": req.CustomerID,
+			"status":      req.Status,
+			"price_id":    req.PriceID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, subscriptions)
+}
+
+// UpdateSubscription handles partial subscription update requests
+func (h *StripeHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateSubscriptionRequest
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	subscription, err := h.stripeService.UpdateSubscription(r.Context(), subscriptionID, &req)
+	if err != nil {
+		h.handleServiceError(w, err, "update subscription", map[string]interface{}{
+			"subscription_id": subscriptionID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, subscription)
+}
+
+// ChangeSubscriptionPlan handles requests to switch a subscription to a different price
+func (h *StripeHandler) ChangeSubscriptionPlan(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.ChangePlanRequest
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	subscription, err := h.stripeService.ChangeSubscriptionPlan(r.Context(), subscriptionID, &req)
+	if err != nil {
+		h.handleServiceError(w, err, "change subscription plan", map[string]interface{}{
+			"subscription_id": subscriptionID,
+			"price_id":        req.PriceID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, subscription)
+}
+
+// PreviewSubscriptionPlanChange handles plan change preview requests. The target price
+// and proration options are read from the price, proration_behavior and proration_date
+// query parameters.
+func (h *StripeHandler) PreviewSubscriptionPlanChange(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	req := &models.ChangePlanRequest{}
+	query := r.URL.Query()
+	req.PriceID = query.Get("price")
+	req.ProrationBehavior = query.Get("proration_behavior")
+
+	if req.ProrationDate, ok = h.parseTimestampQuery(w, query, "proration_date"); !ok {
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("Validation error: %v", err))
+		return
+	}
+
+	preview, err := h.stripeService.PreviewSubscriptionPlanChange(r.Context(), subscriptionID, req)
+	if err != nil {
+		h.handleServiceError(w, err, "preview plan change", map[string]interface{}{
+			"subscription_id": subscriptionID,
+			"price_id":        req.PriceID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, preview)
+}
+
+// AddSubscriptionItem handles requests to add a price to a subscription
+func (h *StripeHandler) AddSubscriptionItem(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.AddSubscriptionItemRequest
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	item, err := h.stripeService.AddSubscriptionItem(r.Context(), subscriptionID, &req)
+	if err != nil {
+		h.handleServiceError(w, err, "add subscription item", map[string]interface{}{
+			"subscription_id": subscriptionID,
+			"price_id":        req.PriceID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, item)
+}
+
+// UpdateSubscriptionItem handles partial subscription item update requests
+func (h *StripeHandler) UpdateSubscriptionItem(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	itemID, ok := h.extractPathParameter(w, r, "item_id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateSubscriptionItemRequest
+	if !h.parseAndValidateJSON(w, r, &req) {
+		return
+	}
+
+	item, err := h.stripeService.UpdateSubscriptionItem(r.Context(), subscriptionID, itemID, &req)
+	if err != nil {
+		h.handleServiceError(w, err, "update subscription item", map[string]interface{}{
+			"subscription_id": subscriptionID,
+			"item_id":         itemID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, item)
+}
+
+// RemoveSubscriptionItem handles subscription item removal requests. Options are read
+// from the proration_behavior and clear_usage query parameters.
+func (h *StripeHandler) RemoveSubscriptionItem(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	itemID, ok := h.extractPathParameter(w, r, "item_id")
+	if !ok {
+		return
+	}
+
+	req := &models.RemoveSubscriptionItemRequest{}
+	query := r.URL.Query()
+	req.ProrationBehavior = query.Get("proration_behavior")
+
+	clearUsage, ok := h.parseBoolQuery(w, query, "clear_usage")
+	if !ok {
+		return
+	}
+	req.ClearUsage = clearUsage != nil && *clearUsage
+
+	if err := h.validator.Struct(req); err != nil {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("Validation error: %v", err))
+		return
+	}
+
+	deleted, err := h.stripeService.RemoveSubscriptionItem(r.Context(), subscriptionID, itemID, req)
+	if err != nil {
+		h.handleServiceError(w, err, "remove subscription item", map[string]interface{}{
+			"subscription_id": subscriptionID,
+			"item_id":         itemID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, deleted)
+}
+
+// EndSubscriptionTrial handles requests to end a subscription's trial early
+func (h *StripeHandler) EndSubscriptionTrial(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	subscription, err := h.stripeService.EndSubscriptionTrial(r.Context(), subscriptionID)
+	if err != nil {
+		h.handleServiceError(w, err, "end subscription trial", map[string]interface{}{
+			"subscription_id": subscriptionID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, subscription)
+}
+
+// PauseSubscription handles requests to pause subscription payment collection.
+// The request body is optional.
+func (h *StripeHandler) PauseSubscription(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.PauseSubscriptionRequest
+	if !h.parseOptionalJSON(w, r, &req) {
+		return
+	}
+
+	subscription, err := h.stripeService.PauseSubscription(r.Context(), subscriptionID, &req)
+	if err != nil {
+		h.handleServiceError(w, err, "pause subscription", map[string]interface{}{
+			"subscription_id": subscriptionID,
+			"behavior":        req.Behavior,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, subscription)
+}
+
+// ResumeSubscription handles requests to resume subscription payment collection
+func (h *StripeHandler) ResumeSubscription(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, ok := h.extractPathParameter(w, r, "id")
+	if !ok {
+		return
+	}
+
+	subscription, err := h.stripeService.ResumeSubscription(r.Context(), subscriptionID)
+	if err != nil {
+		h.handleServiceError(w, err, "resume subscription", map[string]interface{}{
+			"subscription_id": subscriptionID,
+		})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, subscription)
+}
+
+// Helper methods for response handling
+
+func (h *StripeHandler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
+
+func (h *StripeHandler) writeError(w http.ResponseWriter, status int, message string) {
+	writeError(w, status, message)
+}
+
+// writeJSON encodes data as a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("Error encoding JSON response: %v", err)
+	}
+}
+
+// writeError writes a JSON error response with the given status code
+func writeError(w http.ResponseWriter, status int, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 
@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"stripe-service/internal/models"
+	"stripe-service/internal/service"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
@@ -76,13 +78,51 @@ func (m *MockStripeService) ListCustomers(ctx context.Context, req *models.ListC
 	}, nil
 }
 
+func (m *MockStripeService) UpdateCustomer(ctx context.Context, customerID string, req *models.UpdateCustomerRequest) (*models.Customer, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	customer := &models.Customer{
+		ID:        customerID,
+		Email:     "test@example.com",
+		Name:      "John Doe",
+		Metadata:  req.Metadata,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if req.Email != nil {
+		customer.Email = *req.Email
+	}
+	if req.Name != nil {
+		customer.Name = *req.Name
+	}
+	return customer, nil
+}
+
+func (m *MockStripeService) DeleteCustomer(ctx context.Context, customerID string) (*models.DeletedCustomer, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.DeletedCustomer{
+		ID:      customerID,
+		Deleted: true,
+	}, nil
+}
+
 func (m *MockStripeService) CreatePaymentIntent(ctx context.Context, req *models.CreatePaymentIntentRequest) (*models.PaymentIntent, error) {
 	if m.shouldError {
 		return nil, errors.New(m.errorMsg)
 	}
+	if req.Currency == "abc" {
+		return nil, &service.ValidationError{Field: "currency", Message: `"abc" is not an ISO 4217 currency code`}
+	}
+	amount := req.Amount
+	if req.PriceID != "" {
+		amount = 900 * req.Quantity
+	}
 	return &models.PaymentIntent{
 		ID:           "pi_test123",
-		Amount:       req.Amount,
+		Amount:       amount,
 		Currency:     req.Currency,
 		Status:       "requires_payment_method",
 		ClientSecret: "pi_test123_secret_456",
@@ -106,6 +146,97 @@ func (m *MockStripeService) ConfirmPaymentIntent(ctx context.Context, paymentInt
 	}, nil
 }
 
+func (m *MockStripeService) GetPaymentIntent(ctx context.Context, paymentIntentID string) (*models.PaymentIntent, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.PaymentIntent{
+		ID:        paymentIntentID,
+		Amount:    1000,
+		Currency:  "usd",
+		Status:    "requires_payment_method",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) UpdatePaymentIntent(ctx context.Context, paymentIntentID string, req *models.UpdatePaymentIntentRequest) (*models.PaymentIntent, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	paymentIntent := &models.PaymentIntent{
+		ID:        paymentIntentID,
+		Amount:    1000,
+		Currency:  "usd",
+		Status:    "requires_payment_method",
+		Metadata:  req.Metadata,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if req.Amount != nil {
+		paymentIntent.Amount = *req.Amount
+	}
+	if req.Description != nil {
+		paymentIntent.Description = *req.Description
+	}
+	return paymentIntent, nil
+}
+
+func (m *MockStripeService) CancelPaymentIntent(ctx context.Context, paymentIntentID string, req *models.CancelPaymentIntentRequest) (*models.PaymentIntent, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.PaymentIntent{
+		ID:                 paymentIntentID,
+		Amount:             1000,
+		Currency:           "usd",
+		Status:             "canceled",
+		CancellationReason: req.CancellationReason,
+		CreatedAt:          time.Now(),
+		UpdatedAt:          time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) CapturePaymentIntent(ctx context.Context, paymentIntentID string, req *models.CapturePaymentIntentRequest) (*models.PaymentIntent, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	if req.AmountToCapture > 1000 {
+		return nil, &service.ValidationError{Field: "amount_to_capture", Message: "exceeds the authorized amount of 1000"}
+	}
+	amountReceived := int64(1000)
+	if req.AmountToCapture > 0 {
+		amountReceived = req.AmountToCapture
+	}
+	return &models.PaymentIntent{
+		ID:             paymentIntentID,
+		Amount:         1000,
+		AmountReceived: amountReceived,
+		Currency:       "usd",
+		Status:         "succeeded",
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) ListPaymentIntents(ctx context.Context, req *models.ListPaymentIntentsRequest) (*models.ListPaymentIntentsResponse, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.ListPaymentIntentsResponse{
+		PaymentIntents: []models.PaymentIntent{
+			{
+				ID:         "pi_test1",
+				Amount:     1000,
+				Currency:   "usd",
+				Status:     "succeeded",
+				CustomerID: req.CustomerID,
+			},
+		},
+		HasMore: false,
+	}, nil
+}
+
 func (m *MockStripeService) CreateProduct(ctx context.Context, req *models.CreateProductRequest) (*models.Product, error) {
 	if m.shouldError {
 		return nil, errors.New(m.errorMsg)
@@ -121,44 +252,398 @@ func (m *MockStripeService) CreateProduct(ctx context.Context, req *models.Creat
 	}, nil
 }
 
+func (m *MockStripeService) GetProduct(ctx context.Context, productID string) (*models.Product, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.Product{
+		ID:        productID,
+		Name:      "Test Product",
+		Active:    true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) ListProducts(ctx context.Context, req *models.ListProductsRequest) (*models.ListProductsResponse, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+	return &models.ListProductsResponse{
+		Products: []models.Product{
+			{ID: "prod_test1", Name: "Test Product", Active: active},
+		},
+		HasMore: false,
+	}, nil
+}
+
+func (m *MockStripeService) UpdateProduct(ctx context.Context, productID string, req *models.UpdateProductRequest) (*models.Product, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	product := &models.Product{
+		ID:        productID,
+		Name:      "Test Product",
+		Active:    true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if req.Name != nil {
+		product.Name = *req.Name
+	}
+	if req.Active != nil {
+		product.Active = *req.Active
+	}
+	return product, nil
+}
+
+func (m *MockStripeService) ArchiveProduct(ctx context.Context, productID string) (*models.Product, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.Product{
+		ID:        productID,
+		Name:      "Test Product",
+		Active:    false,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) DeleteProduct(ctx context.Context, productID string) (*models.DeletedProduct, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	if productID == "prod_with_prices" {
+		return nil, &service.ValidationError{Field: "product", Message: productID + " has prices and cannot be deleted; archive it instead"}
+	}
+	return &models.DeletedProduct{
+		ID:      productID,
+		Deleted: true,
+	}, nil
+}
+
 func (m *MockStripeService) CreatePrice(ctx context.Context, req *models.CreatePriceRequest) (*models.Price, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	if req.Type == "recurring" && req.RecurringInterval == "" {
+		return nil, &service.ValidationError{Field: "recurring_interval", Message: "must be one of day, week, month or year for recurring prices"}
+	}
+	return &models.Price{
+		ID:                "price_test123",
+		ProductID:         req.ProductID,
+		UnitAmount:        req.UnitAmount,
+		Currency:          req.Currency,
+		Type:              req.Type,
+		RecurringInterval: req.RecurringInterval,
+		IntervalCount:     req.IntervalCount,
+		UsageType:         req.UsageType,
+		TrialPeriodDays:   req.TrialPeriodDays,
+		Active:            req.Active,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) GetPrice(ctx context.Context, priceID string) (*models.Price, error) {
 	if m.shouldError {
 		return nil, errors.New(m.errorMsg)
 	}
 	return &models.Price{
-		ID:         "price_test123",
-		ProductID:  req.ProductID,
-		UnitAmount: req.UnitAmount,
-		Currency:   req.Currency,
-		Type:       req.Type,
-		Active:     req.Active,
+		ID:         priceID,
+		ProductID:  "prod_test123",
+		UnitAmount: 1500,
+		Currency:   "usd",
+		Type:       "recurring",
+		Active:     true,
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}, nil
 }
 
+func (m *MockStripeService) ListPrices(ctx context.Context, req *models.ListPricesRequest) (*models.ListPricesResponse, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	prices := []models.Price{}
+	for _, lookupKey := range req.LookupKeys {
+		prices = append(prices, models.Price{ID: "price_" + lookupKey, LookupKey: lookupKey, Active: true})
+	}
+	if len(req.LookupKeys) == 0 {
+		prices = append(prices, models.Price{ID: "price_test1", ProductID: req.ProductID, Type: "one_time", Active: true})
+	}
+	return &models.ListPricesResponse{
+		Prices:  prices,
+		HasMore: false,
+	}, nil
+}
+
+func (m *MockStripeService) UpdatePrice(ctx context.Context, priceID string, req *models.UpdatePriceRequest) (*models.Price, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	price := &models.Price{
+		ID:        priceID,
+		Active:    true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if req.LookupKey != nil {
+		price.LookupKey = *req.LookupKey
+	}
+	if req.Active != nil {
+		price.Active = *req.Active
+	}
+	return price, nil
+}
+
 func (m *MockStripeService) CreateSubscription(ctx context.Context, req *models.CreateSubscriptionRequest) (*models.Subscription, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	items := []models.SubscriptionItem{}
+	seen := map[string]bool{}
+	for i, item := range req.Items {
+		if seen[item.PriceID] {
+			return nil, &service.ValidationError{Field: "items", Message: "price " + item.PriceID + " appears on more than one item"}
+		}
+		seen[item.PriceID] = true
+		items = append(items, models.SubscriptionItem{ID: fmt.Sprintf("si_test%d", i), PriceID: item.PriceID, Quantity: item.Quantity})
+	}
+	if len(items) == 0 {
+		items = append(items, models.SubscriptionItem{ID: "si_test0", PriceID: req.PriceID, Quantity: req.Quantity})
+	}
+	subscription := &models.Subscription{
+		ID:            "sub_test123",
+		CustomerID:    req.CustomerID,
+		PriceID:       items[0].PriceID,
+		Items:         items,
+		Status:        "active",
+		TrialSettings: req.TrialSettings,
+		Metadata:      req.Metadata,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	if req.TrialPeriodDays > 0 {
+		trialStart := time.Now()
+		trialEnd := trialStart.AddDate(0, 0, int(req.TrialPeriodDays))
+		subscription.Status = "trialing"
+		subscription.TrialStart = &trialStart
+		subscription.TrialEnd = &trialEnd
+	}
+	return subscription, nil
+}
+
+func (m *MockStripeService) CancelSubscription(ctx context.Context, subscriptionID string, req *models.CancelSubscriptionRequest) (*models.Subscription, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	if req.InvoiceNow != nil && req.Mode != "" && req.Mode != "immediately" {
+		return nil, &service.ValidationError{Field: "invoice_now", Message: "is only allowed when mode is immediately"}
+	}
+	if req.Mode == "at_period_end" {
+		return &models.Subscription{
+			ID:                subscriptionID,
+			Status:            "active",
+			CancelAtPeriodEnd: true,
+			CreatedAt:         time.Now(),
+			UpdatedAt:         time.Now(),
+		}, nil
+	}
+	return &models.Subscription{
+		ID:        subscriptionID,
+		Status:    "canceled",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) UndoSubscriptionCancellation(ctx context.Context, subscriptionID string) (*models.Subscription, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	if subscriptionID == "sub_not_canceling" {
+		return nil, &service.ValidationError{Field: "subscription", Message: "is not scheduled for cancellation"}
+	}
+	return &models.Subscription{
+		ID:        subscriptionID,
+		Status:    "active",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) GetSubscription(ctx context.Context, subscriptionID string) (*models.Subscription, error) {
 	if m.shouldError {
 		return nil, errors.New(m.errorMsg)
 	}
 	return &models.Subscription{
-		ID:         "sub_test123",
-		CustomerID: req.CustomerID,
-		PriceID:    req.PriceID,
+		ID:         subscriptionID,
+		CustomerID: "cus_test123",
+		PriceID:    "price_test123",
 		Status:     "active",
-		Metadata:   req.Metadata,
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}, nil
 }
 
-func (m *MockStripeService) CancelSubscription(ctx context.Context, subscriptionID string) (*models.Subscription, error) {
+func (m *MockStripeService) ListSubscriptions(ctx context.Context, req *models.ListSubscriptionsRequest) (*models.ListSubscriptionsResponse, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.ListSubscriptionsResponse{
+		Subscriptions: []models.Subscription{
+			{ID: "sub_test1", CustomerID: req.CustomerID, PriceID: req.PriceID, Status: "active"},
+			{ID: "sub_test2", CustomerID: req.CustomerID, PriceID: req.PriceID, Status: "trialing"},
+		},
+		HasMore: false,
+	}, nil
+}
+
+func (m *MockStripeService) UpdateSubscription(ctx context.Context, subscriptionID string, req *models.UpdateSubscriptionRequest) (*models.Subscription, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	if req.CollectionMethod != nil && *req.CollectionMethod == "send_invoice" && req.DaysUntilDue == nil {
+		return nil, &service.ValidationError{Field: "days_until_due", Message: "is required when collection_method is send_invoice"}
+	}
+	subscription := &models.Subscription{
+		ID:        subscriptionID,
+		Status:    "active",
+		Metadata:  req.Metadata,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if req.DefaultPaymentMethodID != nil {
+		subscription.DefaultPaymentMethodID = *req.DefaultPaymentMethodID
+	}
+	return subscription, nil
+}
+
+func (m *MockStripeService) ChangeSubscriptionPlan(ctx context.Context, subscriptionID string, req *models.ChangePlanRequest) (*models.Subscription, error) {
 	if m.shouldError {
 		return nil, errors.New(m.errorMsg)
 	}
+	if req.PriceID == "price_current" {
+		return nil, &service.ValidationError{Field: "price_id", Message: "is already the subscription's price"}
+	}
 	return &models.Subscription{
 		ID:        subscriptionID,
-		Status:    "canceled",
+		PriceID:   req.PriceID,
+		Status:    "active",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) PreviewSubscriptionPlanChange(ctx context.Context, subscriptionID string, req *models.ChangePlanRequest) (*models.PlanChangePreview, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	if req.PriceID == "price_current" {
+		return nil, &service.ValidationError{Field: "price_id", Message: "is already the subscription's price"}
+	}
+	return &models.PlanChangePreview{
+		SubscriptionID:  subscriptionID,
+		PriceID:         req.PriceID,
+		Currency:        "usd",
+		ProrationDate:   req.ProrationDate,
+		ProrationAmount: 1000,
+		AmountDue:       3000,
+		Lines: []models.ProrationLine{
+			{Description: "Unused time on Basic", Amount: -1000, Currency: "usd", Quantity: 1},
+			{Description: "Remaining time on Pro", Amount: 2000, Currency: "usd", Quantity: 1},
+		},
+	}, nil
+}
+
+func (m *MockStripeService) AddSubscriptionItem(ctx context.Context, subscriptionID string, req *models.AddSubscriptionItemRequest) (*models.SubscriptionItem, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.SubscriptionItem{
+		ID:        "si_test123",
+		PriceID:   req.PriceID,
+		Quantity:  req.Quantity,
+		Metadata:  req.Metadata,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) UpdateSubscriptionItem(ctx context.Context, subscriptionID, itemID string, req *models.UpdateSubscriptionItemRequest) (*models.SubscriptionItem, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	if itemID == "si_other" {
+		return nil, &service.ValidationError{Field: "item_id", Message: "does not belong to subscription " + subscriptionID}
+	}
+	item := &models.SubscriptionItem{
+		ID:        itemID,
+		PriceID:   "price_test123",
+		Quantity:  1,
+		CreatedAt: time.Now(),
+	}
+	if req.Quantity != nil {
+		item.Quantity = *req.Quantity
+	}
+	return item, nil
+}
+
+func (m *MockStripeService) RemoveSubscriptionItem(ctx context.Context, subscriptionID, itemID string, req *models.RemoveSubscriptionItemRequest) (*models.DeletedSubscriptionItem, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	if itemID == "si_only" {
+		return nil, &service.ValidationError{Field: "item_id", Message: "is the subscription's only item; cancel the subscription instead"}
+	}
+	return &models.DeletedSubscriptionItem{ID: itemID, Deleted: true}, nil
+}
+
+func (m *MockStripeService) EndSubscriptionTrial(ctx context.Context, subscriptionID string) (*models.Subscription, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	if subscriptionID == "sub_active" {
+		return nil, &service.ValidationError{Field: "subscription", Message: "is not in a trial"}
+	}
+	trialEnd := time.Now()
+	return &models.Subscription{
+		ID:        subscriptionID,
+		Status:    "active",
+		TrialEnd:  &trialEnd,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) PauseSubscription(ctx context.Context, subscriptionID string, req *models.PauseSubscriptionRequest) (*models.Subscription, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	if req.ResumesAt > 0 && req.ResumesAt <= time.Now().Unix() {
+		return nil, &service.ValidationError{Field: "resumes_at", Message: "must be in the future"}
+	}
+	return &models.Subscription{
+		ID:              subscriptionID,
+		Status:          "active",
+		PauseCollection: &models.SubscriptionPause{Behavior: req.Behavior},
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}, nil
+}
+
+func (m *MockStripeService) ResumeSubscription(ctx context.Context, subscriptionID string) (*models.Subscription, error) {
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return &models.Subscription{
+		ID:        subscriptionID,
+		Status:    "active",
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}, nil
@@ -362,12 +847,48 @@ func TestStripeHandler_ListCustomers(t *testing.T) {
 			expectedStatus: http.StatusOK, // Handler silently ignores invalid limit
 		},
 		{
-			name:           "service error",
-			url:            "/customers",
-			shouldError:    true,
-			errorMsg:       "list error",
-			expectedStatus: http.StatusInternalServerError,
-		},
+			name:           "list with ending_before parameter",
+			url:            "/customers?ending_before=cus_1",
+			shouldError:    false,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "cursor and ending_before together",
+			url:            "/customers?cursor=cus_1&ending_before=cus_2",
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "list with email and created filters",
+			url:            "/customers?email=test@example.com&created_gte=1640995200&created_lte=1672531200",
+			shouldError:    false,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "list with invalid created filter",
+			url:            "/customers?created_gte=yesterday",
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "search with query and metadata",
+			url:            "/customers?query=name~'jane'&metadata[tier]=gold&page=page_token",
+			shouldError:    false,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "search with cursor",
+			url:            "/customers?query=name~'jane'&cursor=cus_1",
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			url:            "/customers",
+			shouldError:    true,
+			errorMsg:       "list error",
+			expectedStatus: http.StatusInternalServerError,
+		},
 	}
 
 	for _, tt := range tests {
@@ -392,46 +913,2040 @@ func TestStripeHandler_ListCustomers(t *testing.T) {
 	}
 }
 
+func TestStripeHandler_UpdateCustomer(t *testing.T) {
+	tests := []struct {
+		name           string
+		customerID     string
+		requestBody    string
+		shouldError    bool
+		errorMsg       string
+		expectedStatus int
+	}{
+		{
+			name:           "valid partial update",
+			customerID:     "cus_123",
+			requestBody:    `{"name":"Jane Doe","metadata":{"tier":"gold","legacy":""}}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "empty update",
+			customerID:     "cus_123",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid email",
+			customerID:     "cus_123",
+			requestBody:    `{"email":"not-an-email"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			customerID:     "cus_123",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty customer ID",
+			customerID:     "",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			customerID:     "cus_123",
+			requestBody:    `{"name":"Jane Doe"}`,
+			shouldError:    true,
+			errorMsg:       "update error",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    tt.errorMsg,
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("PATCH", "/customers/"+tt.customerID, bytes.NewBufferString(tt.requestBody))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.customerID})
+			rr := httptest.NewRecorder()
+
+			handler.UpdateCustomer(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_DeleteCustomer(t *testing.T) {
+	tests := []struct {
+		name           string
+		customerID     string
+		shouldError    bool
+		errorMsg       string
+		expectedStatus int
+	}{
+		{
+			name:           "valid customer deletion",
+			customerID:     "cus_123",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "empty customer ID",
+			customerID:     "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			customerID:     "cus_123",
+			shouldError:    true,
+			errorMsg:       "delete error",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    tt.errorMsg,
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+			}
+
+			req := httptest.NewRequest("DELETE", "/customers/"+tt.customerID, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.customerID})
+			rr := httptest.NewRecorder()
+
+			handler.DeleteCustomer(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response models.DeletedCustomer
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Error unmarshaling response: %v", err)
+				}
+				if response.ID != tt.customerID || !response.Deleted {
+					t.Errorf("Expected deleted customer %s, got %+v", tt.customerID, response)
+				}
+			}
+		})
+	}
+}
+
 func TestStripeHandler_CreatePaymentIntent(t *testing.T) {
 	tests := []struct {
 		name           string
 		requestBody    interface{}
 		shouldError    bool
-		errorMsg       string
+		errorMsg       string
+		expectedStatus int
+	}{
+		{
+			name: "valid payment intent creation",
+			requestBody: models.CreatePaymentIntentRequest{
+				Amount:   1000,
+				Currency: "usd",
+			},
+			shouldError:    false,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "priced from a price in another currency",
+			requestBody: models.CreatePaymentIntentRequest{
+				Currency: "eur",
+				PriceID:  "price_123",
+				Quantity: 2,
+			},
+			shouldError:    false,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "amount together with price ID",
+			requestBody: models.CreatePaymentIntentRequest{
+				Amount:   1000,
+				Currency: "eur",
+				PriceID:  "price_123",
+			},
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unsupported currency",
+			requestBody: models.CreatePaymentIntentRequest{
+				Amount:   1000,
+				Currency: "abc",
+			},
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			requestBody:    "invalid json",
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "missing required fields",
+			requestBody: models.CreatePaymentIntentRequest{
+				Amount: 1000,
+				// Missing Currency
+			},
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			requestBody: models.CreatePaymentIntentRequest{
+				Amount:   1000,
+				Currency: "usd",
+			},
+			shouldError:    true,
+			errorMsg:       "payment intent error",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    tt.errorMsg,
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			var body bytes.Buffer
+			if tt.requestBody != "invalid json" {
+				json.NewEncoder(&body).Encode(tt.requestBody)
+			} else {
+				body.WriteString("invalid json")
+			}
+
+			req := httptest.NewRequest("POST", "/payment-intents", &body)
+			rr := httptest.NewRecorder()
+
+			handler.CreatePaymentIntent(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_ConfirmPaymentIntent(t *testing.T) {
+	tests := []struct {
+		name           string
+		paymentID      string
+		requestBody    interface{}
+		shouldError    bool
+		errorMsg       string
+		expectedStatus int
+	}{
+		{
+			name:      "valid payment intent confirmation",
+			paymentID: "pi_123",
+			requestBody: models.ConfirmPaymentIntentRequest{
+				PaymentMethodID: "pm_card_visa",
+			},
+			shouldError:    false,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "empty payment ID",
+			paymentID:      "",
+			requestBody:    models.ConfirmPaymentIntentRequest{},
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			paymentID:      "pi_123",
+			requestBody:    "invalid json",
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "service error",
+			paymentID: "pi_123",
+			requestBody: models.ConfirmPaymentIntentRequest{
+				PaymentMethodID: "pm_card_visa",
+			},
+			shouldError:    true,
+			errorMsg:       "confirmation error",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    tt.errorMsg,
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			var body bytes.Buffer
+			if tt.requestBody != "invalid json" {
+				json.NewEncoder(&body).Encode(tt.requestBody)
+			} else {
+				body.WriteString("invalid json")
+			}
+
+			req := httptest.NewRequest("POST", "/payment-intents/"+tt.paymentID+"/confirm", &body)
+			rr := httptest.NewRecorder()
+
+			// Set up mux vars
+			req = mux.SetURLVars(req, map[string]string{"id": tt.paymentID})
+
+			handler.ConfirmPaymentIntent(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_GetPaymentIntent(t *testing.T) {
+	tests := []struct {
+		name           string
+		paymentID      string
+		shouldError    bool
+		errorMsg       string
+		expectedStatus int
+	}{
+		{
+			name:           "valid payment intent ID",
+			paymentID:      "pi_123",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "empty payment ID",
+			paymentID:      "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			paymentID:      "pi_123",
+			shouldError:    true,
+			errorMsg:       "payment intent not found",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    tt.errorMsg,
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("GET", "/payment-intents/"+tt.paymentID, nil)
+			rr := httptest.NewRecorder()
+
+			req = mux.SetURLVars(req, map[string]string{"id": tt.paymentID})
+
+			handler.GetPaymentIntent(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_ListPaymentIntents(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		shouldError    bool
+		errorMsg       string
+		expectedStatus int
+	}{
+		{
+			name:           "default list",
+			queryParams:    "",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "filter by customer and date range",
+			queryParams:    "?customer=cus_123&created_gte=1700000000&created_lte=1800000000&limit=20",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "filter by status",
+			queryParams:    "?status=requires_capture",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid status",
+			queryParams:    "?status=paid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid timestamp",
+			queryParams:    "?created_gte=yesterday",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "status filter with cursor",
+			queryParams:    "?status=succeeded&cursor=pi_123",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			queryParams:    "",
+			shouldError:    true,
+			errorMsg:       "list error",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    tt.errorMsg,
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("GET", "/payment-intents"+tt.queryParams, nil)
+			rr := httptest.NewRecorder()
+
+			handler.ListPaymentIntents(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_UpdatePaymentIntent(t *testing.T) {
+	amount := int64(2500)
+	zero := int64(0)
+
+	tests := []struct {
+		name           string
+		paymentID      string
+		requestBody    interface{}
+		shouldError    bool
+		errorMsg       string
+		expectedStatus int
+	}{
+		{
+			name:      "valid update",
+			paymentID: "pi_123",
+			requestBody: models.UpdatePaymentIntentRequest{
+				Amount:   &amount,
+				Metadata: map[string]string{"order_id": "order_123"},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "empty payment ID",
+			paymentID:      "",
+			requestBody:    models.UpdatePaymentIntentRequest{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "invalid amount",
+			paymentID: "pi_123",
+			requestBody: models.UpdatePaymentIntentRequest{
+				Amount: &zero,
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			paymentID:      "pi_123",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "service error",
+			paymentID: "pi_123",
+			requestBody: models.UpdatePaymentIntentRequest{
+				Amount: &amount,
+			},
+			shouldError:    true,
+			errorMsg:       "update error",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    tt.errorMsg,
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			var body bytes.Buffer
+			if tt.requestBody != "invalid json" {
+				json.NewEncoder(&body).Encode(tt.requestBody)
+			} else {
+				body.WriteString("invalid json")
+			}
+
+			req := httptest.NewRequest("PATCH", "/payment-intents/"+tt.paymentID, &body)
+			rr := httptest.NewRecorder()
+
+			req = mux.SetURLVars(req, map[string]string{"id": tt.paymentID})
+
+			handler.UpdatePaymentIntent(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_CancelPaymentIntent(t *testing.T) {
+	tests := []struct {
+		name           string
+		paymentID      string
+		requestBody    string
+		shouldError    bool
+		errorMsg       string
+		expectedStatus int
+	}{
+		{
+			name:           "cancel without body",
+			paymentID:      "pi_123",
+			requestBody:    "",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "cancel with reason",
+			paymentID:      "pi_123",
+			requestBody:    `{"cancellation_reason":"requested_by_customer"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid reason",
+			paymentID:      "pi_123",
+			requestBody:    `{"cancellation_reason":"changed_mind"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty payment ID",
+			paymentID:      "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			paymentID:      "pi_123",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			paymentID:      "pi_123",
+			shouldError:    true,
+			errorMsg:       "cancel error",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    tt.errorMsg,
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("POST", "/payment-intents/"+tt.paymentID+"/cancel", strings.NewReader(tt.requestBody))
+			rr := httptest.NewRecorder()
+
+			req = mux.SetURLVars(req, map[string]string{"id": tt.paymentID})
+
+			handler.CancelPaymentIntent(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_CapturePaymentIntent(t *testing.T) {
+	tests := []struct {
+		name           string
+		paymentID      string
+		requestBody    string
+		shouldError    bool
+		errorMsg       string
+		expectedStatus int
+	}{
+		{
+			name:           "capture full amount",
+			paymentID:      "pi_123",
+			requestBody:    "",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "capture partial amount",
+			paymentID:      "pi_123",
+			requestBody:    `{"amount_to_capture":500}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "amount exceeds authorization",
+			paymentID:      "pi_123",
+			requestBody:    `{"amount_to_capture":5000}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "negative amount",
+			paymentID:      "pi_123",
+			requestBody:    `{"amount_to_capture":-5}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty payment ID",
+			paymentID:      "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			paymentID:      "pi_123",
+			shouldError:    true,
+			errorMsg:       "capture error",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    tt.errorMsg,
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("POST", "/payment-intents/"+tt.paymentID+"/capture", strings.NewReader(tt.requestBody))
+			rr := httptest.NewRecorder()
+
+			req = mux.SetURLVars(req, map[string]string{"id": tt.paymentID})
+
+			handler.CapturePaymentIntent(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_CreateProduct(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		shouldError    bool
+		errorMsg       string
+		expectedStatus int
+	}{
+		{
+			name: "valid product creation",
+			requestBody: models.CreateProductRequest{
+				Name:        "Test Product",
+				Description: "Test Description",
+				Active:      true,
+			},
+			shouldError:    false,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid JSON",
+			requestBody:    "invalid json",
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "missing required fields",
+			requestBody: models.CreateProductRequest{
+				Description: "Test Description",
+				// Missing Name
+			},
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			requestBody: models.CreateProductRequest{
+				Name:        "Test Product",
+				Description: "Test Description",
+				Active:      true,
+			},
+			shouldError:    true,
+			errorMsg:       "product error",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    tt.errorMsg,
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			var body bytes.Buffer
+			if tt.requestBody != "invalid json" {
+				json.NewEncoder(&body).Encode(tt.requestBody)
+			} else {
+				body.WriteString("invalid json")
+			}
+
+			req := httptest.NewRequest("POST", "/products", &body)
+			rr := httptest.NewRecorder()
+
+			handler.CreateProduct(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_GetProduct(t *testing.T) {
+	tests := []struct {
+		name           string
+		productID      string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "valid product ID",
+			productID:      "prod_123",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "empty product ID",
+			productID:      "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			productID:      "prod_123",
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "product error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+			}
+
+			req := httptest.NewRequest("GET", "/products/"+tt.productID, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.productID})
+			rr := httptest.NewRecorder()
+
+			handler.GetProduct(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_ListProducts(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		shouldError    bool
+		expectedStatus int
+		expectedActive bool
+	}{
+		{
+			name:           "default listing",
+			query:          "",
+			expectedStatus: http.StatusOK,
+			expectedActive: true,
+		},
+		{
+			name:           "archived products",
+			query:          "?active=false&limit=5&cursor=prod_100",
+			expectedStatus: http.StatusOK,
+			expectedActive: false,
+		},
+		{
+			name:           "invalid active filter",
+			query:          "?active=maybe",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			query:          "?active=true",
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "list error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+			}
+
+			req := httptest.NewRequest("GET", "/products"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			handler.ListProducts(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response models.ListProductsResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Error unmarshaling response: %v", err)
+				}
+				if len(response.Products) != 1 || response.Products[0].Active != tt.expectedActive {
+					t.Errorf("Expected one product with active=%v, got %+v", tt.expectedActive, response.Products)
+				}
+			}
+		})
+	}
+}
+
+func TestStripeHandler_UpdateProduct(t *testing.T) {
+	tests := []struct {
+		name           string
+		productID      string
+		requestBody    string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "valid partial update",
+			productID:      "prod_123",
+			requestBody:    `{"name":"Pro plan","images":["https://example.com/pro.png"],"default_price_id":"price_123","metadata":{"legacy":""}}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "remove all images",
+			productID:      "prod_123",
+			requestBody:    `{"images":[]}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid image URL",
+			productID:      "prod_123",
+			requestBody:    `{"images":["not a url"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty name",
+			productID:      "prod_123",
+			requestBody:    `{"name":""}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty product ID",
+			productID:      "",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			productID:      "prod_123",
+			requestBody:    `{"active":false}`,
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "update error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("PATCH", "/products/"+tt.productID, bytes.NewBufferString(tt.requestBody))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.productID})
+			rr := httptest.NewRecorder()
+
+			handler.UpdateProduct(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_ArchiveAndDeleteProduct(t *testing.T) {
+	tests := []struct {
+		name           string
+		productID      string
+		shouldError    bool
+		expectedStatus map[string]int
+	}{
+		{
+			name:           "product without prices",
+			productID:      "prod_123",
+			expectedStatus: map[string]int{"archive": http.StatusOK, "delete": http.StatusOK},
+		},
+		{
+			name:           "product with prices",
+			productID:      "prod_with_prices",
+			expectedStatus: map[string]int{"archive": http.StatusOK, "delete": http.StatusBadRequest},
+		},
+		{
+			name:           "empty product ID",
+			productID:      "",
+			expectedStatus: map[string]int{"archive": http.StatusBadRequest, "delete": http.StatusBadRequest},
+		},
+		{
+			name:           "service error",
+			productID:      "prod_123",
+			shouldError:    true,
+			expectedStatus: map[string]int{"archive": http.StatusInternalServerError, "delete": http.StatusInternalServerError},
+		},
+	}
+
+	handlers := map[string]func(h *StripeHandler) http.HandlerFunc{
+		"archive": func(h *StripeHandler) http.HandlerFunc { return h.ArchiveProduct },
+		"delete":  func(h *StripeHandler) http.HandlerFunc { return h.DeleteProduct },
+	}
+
+	for op, handlerFunc := range handlers {
+		for _, tt := range tests {
+			t.Run(op+" "+tt.name, func(t *testing.T) {
+				mockService := &MockStripeService{
+					shouldError: tt.shouldError,
+					errorMsg:    "product error",
+				}
+				handler := &StripeHandler{
+					stripeService: mockService,
+				}
+
+				req := httptest.NewRequest("POST", "/products/"+tt.productID, nil)
+				req = mux.SetURLVars(req, map[string]string{"id": tt.productID})
+				rr := httptest.NewRecorder()
+
+				handlerFunc(handler)(rr, req)
+
+				if status := rr.Code; status != tt.expectedStatus[op] {
+					t.Errorf("Expected status code %d, got %d", tt.expectedStatus[op], status)
+				}
+			})
+		}
+	}
+}
+
+func TestStripeHandler_CreatePrice(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		shouldError    bool
+		errorMsg       string
+		expectedStatus int
+	}{
+		{
+			name: "valid price creation",
+			requestBody: models.CreatePriceRequest{
+				ProductID:  "prod_123",
+				UnitAmount: 1000,
+				Currency:   "usd",
+				Type:       "one_time",
+			},
+			shouldError:    false,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "quarterly metered price with trial",
+			requestBody: models.CreatePriceRequest{
+				ProductID:         "prod_123",
+				UnitAmount:        1000,
+				Currency:          "usd",
+				Type:              "recurring",
+				RecurringInterval: "month",
+				IntervalCount:     3,
+				UsageType:         "metered",
+				AggregateUsage:    "sum",
+				TrialPeriodDays:   14,
+			},
+			shouldError:    false,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "graduated tiered price",
+			requestBody:    json.RawMessage(`{"product_id":"prod_123","currency":"usd","type":"recurring","recurring_interval":"month","billing_scheme":"tiered","tiers_mode":"graduated","tiers":[{"up_to":1000,"unit_amount":0},{"up_to":"inf","unit_amount":2}]}`),
+			shouldError:    false,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "tier bound that is neither a quantity nor inf",
+			requestBody:    json.RawMessage(`{"product_id":"prod_123","currency":"usd","type":"recurring","recurring_interval":"month","billing_scheme":"tiered","tiers_mode":"graduated","tiers":[{"up_to":"unlimited","unit_amount":2}]}`),
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "recurring price without interval",
+			requestBody: models.CreatePriceRequest{
+				ProductID:  "prod_123",
+				UnitAmount: 1000,
+				Currency:   "usd",
+				Type:       "recurring",
+			},
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid usage type",
+			requestBody: models.CreatePriceRequest{
+				ProductID:         "prod_123",
+				UnitAmount:        1000,
+				Currency:          "usd",
+				Type:              "recurring",
+				RecurringInterval: "month",
+				UsageType:         "prepaid",
+			},
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			requestBody:    "invalid json",
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "missing required fields",
+			requestBody: models.CreatePriceRequest{
+				UnitAmount: 1000,
+				Currency:   "usd",
+				// Missing ProductID
+			},
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			requestBody: models.CreatePriceRequest{
+				ProductID:  "prod_123",
+				UnitAmount: 1000,
+				Currency:   "usd",
+				Type:       "one_time",
+			},
+			shouldError:    true,
+			errorMsg:       "price error",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    tt.errorMsg,
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			var body bytes.Buffer
+			if tt.requestBody != "invalid json" {
+				json.NewEncoder(&body).Encode(tt.requestBody)
+			} else {
+				body.WriteString("invalid json")
+			}
+
+			req := httptest.NewRequest("POST", "/prices", &body)
+			rr := httptest.NewRecorder()
+
+			handler.CreatePrice(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_GetPrice(t *testing.T) {
+	tests := []struct {
+		name           string
+		priceID        string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "valid price ID",
+			priceID:        "price_123",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "empty price ID",
+			priceID:        "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			priceID:        "price_123",
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "price error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+			}
+
+			req := httptest.NewRequest("GET", "/prices/"+tt.priceID, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.priceID})
+			rr := httptest.NewRecorder()
+
+			handler.GetPrice(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_ListPrices(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		shouldError    bool
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name:           "filtered by product",
+			query:          "?product=prod_123&active=true&type=recurring&currency=usd",
+			expectedStatus: http.StatusOK,
+			expectedCount:  1,
+		},
+		{
+			name:           "resolve lookup keys",
+			query:          "?lookup_key=pro_monthly&lookup_key=pro_yearly",
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name:           "too many lookup keys",
+			query:          "?" + strings.Repeat("lookup_key=k&", 11),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid type",
+			query:          "?type=metered",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid currency",
+			query:          "?currency=dollars",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid active filter",
+			query:          "?active=yes please",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			query:          "",
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "list error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("GET", "/prices"+strings.ReplaceAll(tt.query, " ", "%20"), nil)
+			rr := httptest.NewRecorder()
+
+			handler.ListPrices(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response models.ListPricesResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Error unmarshaling response: %v", err)
+				}
+				if len(response.Prices) != tt.expectedCount {
+					t.Errorf("Expected %d prices, got %d", tt.expectedCount, len(response.Prices))
+				}
+			}
+		})
+	}
+}
+
+func TestStripeHandler_UpdatePrice(t *testing.T) {
+	tests := []struct {
+		name           string
+		priceID        string
+		requestBody    string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "transfer lookup key",
+			priceID:        "price_123",
+			requestBody:    `{"lookup_key":"pro_monthly","transfer_lookup_key":true,"nickname":"Pro monthly"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "deactivate",
+			priceID:        "price_123",
+			requestBody:    `{"active":false,"metadata":{"legacy":""}}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "transfer without lookup key",
+			priceID:        "price_123",
+			requestBody:    `{"transfer_lookup_key":true}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			priceID:        "price_123",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty price ID",
+			priceID:        "",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			priceID:        "price_123",
+			requestBody:    `{"active":false}`,
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "update error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("PATCH", "/prices/"+tt.priceID, bytes.NewBufferString(tt.requestBody))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.priceID})
+			rr := httptest.NewRecorder()
+
+			handler.UpdatePrice(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_CreateSubscription(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		shouldError    bool
+		errorMsg       string
+		expectedStatus int
+	}{
+		{
+			name: "valid subscription creation",
+			requestBody: models.CreateSubscriptionRequest{
+				CustomerID: "cus_123",
+				PriceID:    "price_123",
+			},
+			shouldError:    false,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid JSON",
+			requestBody:    "invalid json",
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "missing required fields",
+			requestBody: models.CreateSubscriptionRequest{
+				CustomerID: "cus_123",
+				// Missing PriceID
+			},
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "single price with quantity",
+			requestBody: models.CreateSubscriptionRequest{
+				CustomerID: "cus_123",
+				PriceID:    "price_123",
+				Quantity:   5,
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "multiple items",
+			requestBody: models.CreateSubscriptionRequest{
+				CustomerID: "cus_123",
+				Items: []models.SubscriptionItemRequest{
+					{PriceID: "price_base"},
+					{PriceID: "price_seat", Quantity: 10},
+				},
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "price and items together",
+			requestBody: models.CreateSubscriptionRequest{
+				CustomerID: "cus_123",
+				PriceID:    "price_123",
+				Items:      []models.SubscriptionItemRequest{{PriceID: "price_seat"}},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "duplicate item price",
+			requestBody: models.CreateSubscriptionRequest{
+				CustomerID: "cus_123",
+				Items: []models.SubscriptionItemRequest{
+					{PriceID: "price_seat", Quantity: 2},
+					{PriceID: "price_seat", Quantity: 3},
+				},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "fourteen day trial",
+			requestBody: models.CreateSubscriptionRequest{
+				CustomerID:      "cus_123",
+				PriceID:         "price_123",
+				TrialPeriodDays: 14,
+				TrialSettings: &models.SubscriptionTrialSettings{
+					EndBehavior: models.SubscriptionTrialEndBehavior{MissingPaymentMethod: "cancel"},
+				},
+				PaymentBehavior: "default_incomplete",
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "trial days and trial end together",
+			requestBody: models.CreateSubscriptionRequest{
+				CustomerID:      "cus_123",
+				PriceID:         "price_123",
+				TrialPeriodDays: 14,
+				TrialEnd:        time.Now().AddDate(0, 0, 14).Unix(),
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid missing payment method behavior",
+			requestBody: models.CreateSubscriptionRequest{
+				CustomerID:      "cus_123",
+				PriceID:         "price_123",
+				TrialPeriodDays: 14,
+				TrialSettings: &models.SubscriptionTrialSettings{
+					EndBehavior: models.SubscriptionTrialEndBehavior{MissingPaymentMethod: "ignore"},
+				},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid payment behavior",
+			requestBody: models.CreateSubscriptionRequest{
+				CustomerID:      "cus_123",
+				PriceID:         "price_123",
+				PaymentBehavior: "charge_later",
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			requestBody: models.CreateSubscriptionRequest{
+				CustomerID: "cus_123",
+				PriceID:    "price_123",
+			},
+			shouldError:    true,
+			errorMsg:       "subscription error",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    tt.errorMsg,
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			var body bytes.Buffer
+			if tt.requestBody != "invalid json" {
+				json.NewEncoder(&body).Encode(tt.requestBody)
+			} else {
+				body.WriteString("invalid json")
+			}
+
+			req := httptest.NewRequest("POST", "/subscriptions", &body)
+			rr := httptest.NewRecorder()
+
+			handler.CreateSubscription(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_CancelSubscription(t *testing.T) {
+	tests := []struct {
+		name                      string
+		subscriptionID            string
+		query                     string
+		shouldError               bool
+		errorMsg                  string
+		expectedStatus            int
+		expectedCancelAtPeriodEnd bool
+	}{
+		{
+			name:           "valid subscription cancellation",
+			subscriptionID: "sub_123",
+			shouldError:    false,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "immediate cancellation with proration and final invoice",
+			subscriptionID: "sub_123",
+			query:          "?mode=immediately&prorate=true&invoice_now=true",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:                      "cancel at period end",
+			subscriptionID:            "sub_123",
+			query:                     "?mode=at_period_end",
+			expectedStatus:            http.StatusOK,
+			expectedCancelAtPeriodEnd: true,
+		},
+		{
+			name:           "cancel at timestamp",
+			subscriptionID: "sub_123",
+			query:          fmt.Sprintf("?mode=at_timestamp&cancel_at=%d&prorate=false", time.Now().Add(72*time.Hour).Unix()),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "cancel at timestamp without timestamp",
+			subscriptionID: "sub_123",
+			query:          "?mode=at_timestamp",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invoice now with scheduled cancellation",
+			subscriptionID: "sub_123",
+			query:          "?mode=at_period_end&invoice_now=true",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid mode",
+			subscriptionID: "sub_123",
+			query:          "?mode=later",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid cancel_at",
+			subscriptionID: "sub_123",
+			query:          "?mode=at_timestamp&cancel_at=tomorrow",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid prorate",
+			subscriptionID: "sub_123",
+			query:          "?prorate=maybe",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty subscription ID",
+			subscriptionID: "",
+			shouldError:    false,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			subscriptionID: "sub_123",
+			shouldError:    true,
+			errorMsg:       "cancellation error",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    tt.errorMsg,
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("DELETE", "/subscriptions/"+tt.subscriptionID+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			// Set up mux vars
+			req = mux.SetURLVars(req, map[string]string{"id": tt.subscriptionID})
+
+			handler.CancelSubscription(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response models.Subscription
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Error unmarshaling response: %v", err)
+				}
+				if response.CancelAtPeriodEnd != tt.expectedCancelAtPeriodEnd {
+					t.Errorf("Expected cancel_at_period_end %v, got %v", tt.expectedCancelAtPeriodEnd, response.CancelAtPeriodEnd)
+				}
+			}
+		})
+	}
+}
+
+func TestStripeHandler_UndoSubscriptionCancellation(t *testing.T) {
+	tests := []struct {
+		name           string
+		subscriptionID string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "scheduled cancellation",
+			subscriptionID: "sub_123",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "subscription not scheduled for cancellation",
+			subscriptionID: "sub_not_canceling",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty subscription ID",
+			subscriptionID: "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			subscriptionID: "sub_123",
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "undo error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+			}
+
+			req := httptest.NewRequest("POST", "/subscriptions/"+tt.subscriptionID+"/undo-cancellation", nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.subscriptionID})
+			rr := httptest.NewRecorder()
+
+			handler.UndoSubscriptionCancellation(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_GetSubscription(t *testing.T) {
+	tests := []struct {
+		name           string
+		subscriptionID string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "valid subscription ID",
+			subscriptionID: "sub_123",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "empty subscription ID",
+			subscriptionID: "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			subscriptionID: "sub_123",
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "get error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+			}
+
+			req := httptest.NewRequest("GET", "/subscriptions/"+tt.subscriptionID, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.subscriptionID})
+			rr := httptest.NewRecorder()
+
+			handler.GetSubscription(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_ListSubscriptions(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "filtered by customer, status and price",
+			query:          "?customer=cus_123&status=active&price=price_123&limit=10",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "include canceled",
+			query:          "?status=all&cursor=sub_100",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid status",
+			query:          "?status=expired",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			query:          "",
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "list error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("GET", "/subscriptions"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			handler.ListSubscriptions(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response models.ListSubscriptionsResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Error unmarshaling response: %v", err)
+				}
+				if len(response.Subscriptions) != 2 {
+					t.Errorf("Expected 2 subscriptions, got %d", len(response.Subscriptions))
+				}
+			}
+		})
+	}
+}
+
+func TestStripeHandler_UpdateSubscription(t *testing.T) {
+	tests := []struct {
+		name           string
+		subscriptionID string
+		requestBody    string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "update payment method and metadata",
+			subscriptionID: "sub_123",
+			requestBody:    `{"default_payment_method_id":"pm_123","metadata":{"plan":"pro","legacy":""}}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "switch to invoice collection",
+			subscriptionID: "sub_123",
+			requestBody:    `{"collection_method":"send_invoice","days_until_due":30}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invoice collection without days until due",
+			subscriptionID: "sub_123",
+			requestBody:    `{"collection_method":"send_invoice"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid collection method",
+			subscriptionID: "sub_123",
+			requestBody:    `{"collection_method":"manual"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			subscriptionID: "sub_123",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty subscription ID",
+			subscriptionID: "",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			subscriptionID: "sub_123",
+			requestBody:    `{"default_payment_method_id":"pm_123"}`,
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "update error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("PATCH", "/subscriptions/"+tt.subscriptionID, bytes.NewBufferString(tt.requestBody))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.subscriptionID})
+			rr := httptest.NewRecorder()
+
+			handler.UpdateSubscription(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_ChangeSubscriptionPlan(t *testing.T) {
+	tests := []struct {
+		name           string
+		subscriptionID string
+		requestBody    string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "upgrade with default proration",
+			subscriptionID: "sub_123",
+			requestBody:    `{"price_id":"price_pro"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "upgrade invoiced immediately at previewed date",
+			subscriptionID: "sub_123",
+			requestBody:    `{"price_id":"price_pro","proration_behavior":"always_invoice","proration_date":1700000000}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "same price",
+			subscriptionID: "sub_123",
+			requestBody:    `{"price_id":"price_current"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing price",
+			subscriptionID: "sub_123",
+			requestBody:    `{"proration_behavior":"none"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid proration behavior",
+			subscriptionID: "sub_123",
+			requestBody:    `{"price_id":"price_pro","proration_behavior":"sometimes"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty subscription ID",
+			subscriptionID: "",
+			requestBody:    `{"price_id":"price_pro"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			subscriptionID: "sub_123",
+			requestBody:    `{"price_id":"price_pro"}`,
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "change plan error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("POST", "/subscriptions/"+tt.subscriptionID+"/change-plan", bytes.NewBufferString(tt.requestBody))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.subscriptionID})
+			rr := httptest.NewRecorder()
+
+			handler.ChangeSubscriptionPlan(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestStripeHandler_PreviewSubscriptionPlanChange(t *testing.T) {
+	tests := []struct {
+		name           string
+		subscriptionID string
+		query          string
+		shouldError    bool
+		expectedStatus int
+	}{
+		{
+			name:           "preview upgrade",
+			subscriptionID: "sub_123",
+			query:          "?price=price_pro&proration_behavior=always_invoice&proration_date=1700000000",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing price",
+			subscriptionID: "sub_123",
+			query:          "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "same price",
+			subscriptionID: "sub_123",
+			query:          "?price=price_current",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid proration date",
+			subscriptionID: "sub_123",
+			query:          "?price=price_pro&proration_date=now",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid proration behavior",
+			subscriptionID: "sub_123",
+			query:          "?price=price_pro&proration_behavior=sometimes",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty subscription ID",
+			subscriptionID: "",
+			query:          "?price=price_pro",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			subscriptionID: "sub_123",
+			query:          "?price=price_pro",
+			shouldError:    true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockStripeService{
+				shouldError: tt.shouldError,
+				errorMsg:    "preview error",
+			}
+			handler := &StripeHandler{
+				stripeService: mockService,
+				validator:     validator.New(),
+			}
+
+			req := httptest.NewRequest("GET", "/subscriptions/"+tt.subscriptionID+"/change-plan/preview"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.subscriptionID})
+			rr := httptest.NewRecorder()
+
+			handler.PreviewSubscriptionPlanChange(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response models.PlanChangePreview
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Error unmarshaling response: %v", err)
+				}
+				if response.ProrationDate != 1700000000 {
+					t.Errorf("Expected proration_date 1700000000, got %d", response.ProrationDate)
+				}
+				if len(response.Lines) != 2 {
+					t.Errorf("Expected 2 proration lines, got %d", len(response.Lines))
+				}
+			}
+		})
+	}
+}
+
+func TestStripeHandler_AddSubscriptionItem(t *testing.T) {
+	tests := []struct {
+		name           string
+		subscriptionID string
+		requestBody    string
+		shouldError    bool
 		expectedStatus int
 	}{
 		{
-			name: "valid payment intent creation",
-			requestBody: models.CreatePaymentIntentRequest{
-				Amount:   1000,
-				Currency: "usd",
-			},
-			shouldError:    false,
+			name:           "add seats",
+			subscriptionID: "sub_123",
+			requestBody:    `{"price_id":"price_seat","quantity":3,"proration_behavior":"always_invoice"}`,
 			expectedStatus: http.StatusCreated,
 		},
 		{
-			name:           "invalid JSON",
-			requestBody:    "invalid json",
-			shouldError:    false,
+			name:           "missing price",
+			subscriptionID: "sub_123",
+			requestBody:    `{"quantity":3}`,
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name: "missing required fields",
-			requestBody: models.CreatePaymentIntentRequest{
-				Amount: 1000,
-				// Missing Currency
-			},
-			shouldError:    false,
+			name:           "invalid quantity",
+			subscriptionID: "sub_123",
+			requestBody:    `{"price_id":"price_seat","quantity":-1}`,
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name: "service error",
-			requestBody: models.CreatePaymentIntentRequest{
-				Amount:   1000,
-				Currency: "usd",
-			},
+			name:           "empty subscription ID",
+			subscriptionID: "",
+			requestBody:    `{"price_id":"price_seat"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			subscriptionID: "sub_123",
+			requestBody:    `{"price_id":"price_seat"}`,
 			shouldError:    true,
-			errorMsg:       "payment intent error",
 			expectedStatus: http.StatusInternalServerError,
 		},
 	}
@@ -440,24 +2955,18 @@ func TestStripeHandler_CreatePaymentIntent(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := &MockStripeService{
 				shouldError: tt.shouldError,
-				errorMsg:    tt.errorMsg,
+				errorMsg:    "add item error",
 			}
 			handler := &StripeHandler{
 				stripeService: mockService,
 				validator:     validator.New(),
 			}
 
-			var body bytes.Buffer
-			if tt.requestBody != "invalid json" {
-				json.NewEncoder(&body).Encode(tt.requestBody)
-			} else {
-				body.WriteString("invalid json")
-			}
-
-			req := httptest.NewRequest("POST", "/payment-intents", &body)
+			req := httptest.NewRequest("POST", "/subscriptions/"+tt.subscriptionID+"/items", bytes.NewBufferString(tt.requestBody))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.subscriptionID})
 			rr := httptest.NewRecorder()
 
-			handler.CreatePaymentIntent(rr, req)
+			handler.AddSubscriptionItem(rr, req)
 
 			if status := rr.Code; status != tt.expectedStatus {
 				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
@@ -466,46 +2975,49 @@ func TestStripeHandler_CreatePaymentIntent(t *testing.T) {
 	}
 }
 
-func TestStripeHandler_ConfirmPaymentIntent(t *testing.T) {
+func TestStripeHandler_UpdateSubscriptionItem(t *testing.T) {
 	tests := []struct {
 		name           string
-		paymentID      string
-		requestBody    interface{}
+		subscriptionID string
+		itemID         string
+		requestBody    string
 		shouldError    bool
-		errorMsg       string
 		expectedStatus int
 	}{
 		{
-			name:      "valid payment intent confirmation",
-			paymentID: "pi_123",
-			requestBody: models.ConfirmPaymentIntentRequest{
-				PaymentMethodID: "pm_card_visa",
-			},
-			shouldError:    false,
+			name:           "change seat count",
+			subscriptionID: "sub_123",
+			itemID:         "si_123",
+			requestBody:    `{"quantity":12,"proration_behavior":"create_prorations"}`,
 			expectedStatus: http.StatusOK,
 		},
 		{
-			name:           "empty payment ID",
-			paymentID:      "",
-			requestBody:    models.ConfirmPaymentIntentRequest{},
-			shouldError:    false,
+			name:           "item from another subscription",
+			subscriptionID: "sub_123",
+			itemID:         "si_other",
+			requestBody:    `{"quantity":12}`,
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name:           "invalid JSON",
-			paymentID:      "pi_123",
-			requestBody:    "invalid json",
-			shouldError:    false,
+			name:           "zero quantity",
+			subscriptionID: "sub_123",
+			itemID:         "si_123",
+			requestBody:    `{"quantity":0}`,
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name:      "service error",
-			paymentID: "pi_123",
-			requestBody: models.ConfirmPaymentIntentRequest{
-				PaymentMethodID: "pm_card_visa",
-			},
+			name:           "empty item ID",
+			subscriptionID: "sub_123",
+			itemID:         "",
+			requestBody:    `{"quantity":12}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			subscriptionID: "sub_123",
+			itemID:         "si_123",
+			requestBody:    `{"quantity":12}`,
 			shouldError:    true,
-			errorMsg:       "confirmation error",
 			expectedStatus: http.StatusInternalServerError,
 		},
 	}
@@ -514,27 +3026,18 @@ func TestStripeHandler_ConfirmPaymentIntent(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := &MockStripeService{
 				shouldError: tt.shouldError,
-				errorMsg:    tt.errorMsg,
+				errorMsg:    "update item error",
 			}
 			handler := &StripeHandler{
 				stripeService: mockService,
 				validator:     validator.New(),
 			}
 
-			var body bytes.Buffer
-			if tt.requestBody != "invalid json" {
-				json.NewEncoder(&body).Encode(tt.requestBody)
-			} else {
-				body.WriteString("invalid json")
-			}
-
-			req := httptest.NewRequest("POST", "/payment-intents/"+tt.paymentID+"/confirm", &body)
+			req := httptest.NewRequest("PATCH", "/subscriptions/"+tt.subscriptionID+"/items/"+tt.itemID, bytes.NewBufferString(tt.requestBody))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.subscriptionID, "item_id": tt.itemID})
 			rr := httptest.NewRecorder()
 
-			// Set up mux vars
-			req = mux.SetURLVars(req, map[string]string{"id": tt.paymentID})
-
-			handler.ConfirmPaymentIntent(rr, req)
+			handler.UpdateSubscriptionItem(rr, req)
 
 			if status := rr.Code; status != tt.expectedStatus {
 				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
@@ -543,48 +3046,46 @@ func TestStripeHandler_ConfirmPaymentIntent(t *testing.T) {
 	}
 }
 
-func TestStripeHandler_CreateProduct(t *testing.T) {
+func TestStripeHandler_RemoveSubscriptionItem(t *testing.T) {
 	tests := []struct {
 		name           string
-		requestBody    interface{}
+		itemID         string
+		query          string
 		shouldError    bool
-		errorMsg       string
 		expectedStatus int
 	}{
 		{
-			name: "valid product creation",
-			requestBody: models.CreateProductRequest{
-				Name:        "Test Product",
-				Description: "Test Description",
-				Active:      true,
-			},
-			shouldError:    false,
-			expectedStatus: http.StatusCreated,
+			name:           "remove add-on",
+			itemID:         "si_123",
+			query:          "?proration_behavior=none&clear_usage=true",
+			expectedStatus: http.StatusOK,
 		},
 		{
-			name:           "invalid JSON",
-			requestBody:    "invalid json",
-			shouldError:    false,
+			name:           "remove only item",
+			itemID:         "si_only",
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name: "missing required fields",
-			requestBody: models.CreateProductRequest{
-				Description: "Test Description",
-				// Missing Name
-			},
-			shouldError:    false,
+			name:           "invalid proration behavior",
+			itemID:         "si_123",
+			query:          "?proration_behavior=sometimes",
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name: "service error",
-			requestBody: models.CreateProductRequest{
-				Name:        "Test Product",
-				Description: "Test Description",
-				Active:      true,
-			},
+			name:           "invalid clear_usage",
+			itemID:         "si_123",
+			query:          "?clear_usage=all",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty item ID",
+			itemID:         "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			itemID:         "si_123",
 			shouldError:    true,
-			errorMsg:       "product error",
 			expectedStatus: http.StatusInternalServerError,
 		},
 	}
@@ -593,24 +3094,18 @@ func TestStripeHandler_CreateProduct(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := &MockStripeService{
 				shouldError: tt.shouldError,
-				errorMsg:    tt.errorMsg,
+				errorMsg:    "remove item error",
 			}
 			handler := &StripeHandler{
 				stripeService: mockService,
 				validator:     validator.New(),
 			}
 
-			var body bytes.Buffer
-			if tt.requestBody != "invalid json" {
-				json.NewEncoder(&body).Encode(tt.requestBody)
-			} else {
-				body.WriteString("invalid json")
-			}
-
-			req := httptest.NewRequest("POST", "/products", &body)
+			req := httptest.NewRequest("DELETE", "/subscriptions/sub_123/items/"+tt.itemID+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": "sub_123", "item_id": tt.itemID})
 			rr := httptest.NewRecorder()
 
-			handler.CreateProduct(rr, req)
+			handler.RemoveSubscriptionItem(rr, req)
 
 			if status := rr.Code; status != tt.expectedStatus {
 				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
@@ -619,51 +3114,32 @@ func TestStripeHandler_CreateProduct(t *testing.T) {
 	}
 }
 
-func TestStripeHandler_CreatePrice(t *testing.T) {
+func TestStripeHandler_EndSubscriptionTrial(t *testing.T) {
 	tests := []struct {
 		name           string
-		requestBody    interface{}
+		subscriptionID string
 		shouldError    bool
-		errorMsg       string
 		expectedStatus int
 	}{
 		{
-			name: "valid price creation",
-			requestBody: models.CreatePriceRequest{
-				ProductID:  "prod_123",
-				UnitAmount: 1000,
-				Currency:   "usd",
-				Type:       "one_time",
-			},
-			shouldError:    false,
-			expectedStatus: http.StatusCreated,
+			name:           "trialing subscription",
+			subscriptionID: "sub_123",
+			expectedStatus: http.StatusOK,
 		},
 		{
-			name:           "invalid JSON",
-			requestBody:    "invalid json",
-			shouldError:    false,
+			name:           "subscription not in a trial",
+			subscriptionID: "sub_active",
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name: "missing required fields",
-			requestBody: models.CreatePriceRequest{
-				UnitAmount: 1000,
-				Currency:   "usd",
-				// Missing ProductID
-			},
-			shouldError:    false,
+			name:           "empty subscription ID",
+			subscriptionID: "",
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name: "service error",
-			requestBody: models.CreatePriceRequest{
-				ProductID:  "prod_123",
-				UnitAmount: 1000,
-				Currency:   "usd",
-				Type:       "one_time",
-			},
+			name:           "service error",
+			subscriptionID: "sub_123",
 			shouldError:    true,
-			errorMsg:       "price error",
 			expectedStatus: http.StatusInternalServerError,
 		},
 	}
@@ -672,24 +3148,17 @@ func TestStripeHandler_CreatePrice(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := &MockStripeService{
 				shouldError: tt.shouldError,
-				errorMsg:    tt.errorMsg,
+				errorMsg:    "end trial error",
 			}
 			handler := &StripeHandler{
 				stripeService: mockService,
-				validator:     validator.New(),
-			}
-
-			var body bytes.Buffer
-			if tt.requestBody != "invalid json" {
-				json.NewEncoder(&body).Encode(tt.requestBody)
-			} else {
-				body.WriteString("invalid json")
 			}
 
-			req := httptest.NewRequest("POST", "/prices", &body)
+			req := httptest.NewRequest("POST", "/subscriptions/"+tt.subscriptionID+"/end-trial", nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.subscriptionID})
 			rr := httptest.NewRecorder()
 
-			handler.CreatePrice(rr, req)
+			handler.EndSubscriptionTrial(rr, req)
 
 			if status := rr.Code; status != tt.expectedStatus {
 				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
@@ -698,46 +3167,49 @@ func TestStripeHandler_CreatePrice(t *testing.T) {
 	}
 }
 
-func TestStripeHandler_CreateSubscription(t *testing.T) {
+func TestStripeHandler_PauseSubscription(t *testing.T) {
 	tests := []struct {
 		name           string
-		requestBody    interface{}
+		subscriptionID string
+		requestBody    string
 		shouldError    bool
-		errorMsg       string
 		expectedStatus int
 	}{
 		{
-			name: "valid subscription creation",
-			requestBody: models.CreateSubscriptionRequest{
-				CustomerID: "cus_123",
-				PriceID:    "price_123",
-			},
-			shouldError:    false,
-			expectedStatus: http.StatusCreated,
+			name:           "pause without body",
+			subscriptionID: "sub_123",
+			requestBody:    "",
+			expectedStatus: http.StatusOK,
 		},
 		{
-			name:           "invalid JSON",
-			requestBody:    "invalid json",
-			shouldError:    false,
+			name:           "pause with behavior and resume date",
+			subscriptionID: "sub_123",
+			requestBody:    fmt.Sprintf(`{"behavior":"keep_as_draft","resumes_at":%d}`, time.Now().Add(24*time.Hour).Unix()),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "resume date in the past",
+			subscriptionID: "sub_123",
+			requestBody:    `{"resumes_at":1000}`,
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name: "missing required fields",
-			requestBody: models.CreateSubscriptionRequest{
-				CustomerID: "cus_123",
-				// Missing PriceID
-			},
-			shouldError:    false,
+			name:           "invalid behavior",
+			subscriptionID: "sub_123",
+			requestBody:    `{"behavior":"skip"}`,
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name: "service error",
-			requestBody: models.CreateSubscriptionRequest{
-				CustomerID: "cus_123",
-				PriceID:    "price_123",
-			},
+			name:           "empty subscription ID",
+			subscriptionID: "",
+			requestBody:    "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			subscriptionID: "sub_123",
+			requestBody:    "",
 			shouldError:    true,
-			errorMsg:       "subscription error",
 			expectedStatus: http.StatusInternalServerError,
 		},
 	}
@@ -746,24 +3218,18 @@ func TestStripeHandler_CreateSubscription(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := &MockStripeService{
 				shouldError: tt.shouldError,
-				errorMsg:    tt.errorMsg,
+				errorMsg:    "pause error",
 			}
 			handler := &StripeHandler{
 				stripeService: mockService,
 				validator:     validator.New(),
 			}
 
-			var body bytes.Buffer
-			if tt.requestBody != "invalid json" {
-				json.NewEncoder(&body).Encode(tt.requestBody)
-			} else {
-				body.WriteString("invalid json")
-			}
-
-			req := httptest.NewRequest("POST", "/subscriptions", &body)
+			req := httptest.NewRequest("POST", "/subscriptions/"+tt.subscriptionID+"/pause", bytes.NewBufferString(tt.requestBody))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.subscriptionID})
 			rr := httptest.NewRecorder()
 
-			handler.CreateSubscription(rr, req)
+			handler.PauseSubscription(rr, req)
 
 			if status := rr.Code; status != tt.expectedStatus {
 				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
@@ -772,31 +3238,27 @@ func TestStripeHandler_CreateSubscription(t *testing.T) {
 	}
 }
 
-func TestStripeHandler_CancelSubscription(t *testing.T) {
+func TestStripeHandler_ResumeSubscription(t *testing.T) {
 	tests := []struct {
 		name           string
 		subscriptionID string
 		shouldError    bool
-		errorMsg       string
 		expectedStatus int
 	}{
 		{
-			name:           "valid subscription cancellation",
+			name:           "valid resume",
 			subscriptionID: "sub_123",
-			shouldError:    false,
 			expectedStatus: http.StatusOK,
 		},
 		{
 			name:           "empty subscription ID",
 			subscriptionID: "",
-			shouldError:    false,
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
 			name:           "service error",
 			subscriptionID: "sub_123",
 			shouldError:    true,
-			errorMsg:       "cancellation error",
 			expectedStatus: http.StatusInternalServerError,
 		},
 	}
@@ -805,19 +3267,17 @@ func TestStripeHandler_CancelSubscription(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := &MockStripeService{
 				shouldError: tt.shouldError,
-				errorMsg:    tt.errorMsg,
+				errorMsg:    "resume error",
 			}
 			handler := &StripeHandler{
 				stripeService: mockService,
 			}
 
-			req := httptest.NewRequest("DELETE", "/subscriptions/"+tt.subscriptionID, nil)
-			rr := httptest.NewRecorder()
-
-			// Set up mux vars
+			req := httptest.NewRequest("POST", "/subscriptions/"+tt.subscriptionID+"/resume", nil)
 			req = mux.SetURLVars(req, map[string]string{"id": tt.subscriptionID})
+			rr := httptest.NewRecorder()
 
-			handler.CancelSubscription(rr, req)
+			handler.ResumeSubscription(rr, req)
 
 			if status := rr.Code; status != tt.expectedStatus {
 				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, status)
@@ -866,6 +3326,26 @@ func TestStripeHandler_WriteError(t *testing.T) {
 }
 
 // Test ListCustomers with cursor parameter
+func TestParseMetadataQuery(t *testing.T) {
+	req := httptest.NewRequest("GET", "/customers?metadata[tier]=gold&metadata[region]=eu&metadata[]=ignored&email=a@example.com", nil)
+
+	metadata := parseMetadataQuery(req.URL.Query())
+
+	expected := map[string]string{"tier": "gold", "region": "eu"}
+	if len(metadata) != len(expected) {
+		t.Fatalf("Expected %d metadata filters, got %v", len(expected), metadata)
+	}
+	for key, value := range expected {
+		if metadata[key] != value {
+			t.Errorf("Expected metadata[%s] to be %q, got %q", key, value, metadata[key])
+		}
+	}
+
+	if parseMetadataQuery(httptest.NewRequest("GET", "/customers", nil).URL.Query()) != nil {
+		t.Error("Expected nil metadata without metadata parameters")
+	}
+}
+
 func TestStripeHandler_ListCustomers_WithCursor(t *testing.T) {
 	mockService := &MockStripeService{
 		shouldError: false,
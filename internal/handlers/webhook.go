@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"stripe-service/internal/webhooks"
+
+	"github.com/stripe/stripe-go/v76/webhook"
+)
+
+const (
+	// MaxWebhookPayloadBytes caps the size of an incoming webhook body
+	MaxWebhookPayloadBytes = 65536
+
+	// DefaultWebhookTolerance is the maximum age of a webhook signature timestamp
+	DefaultWebhookTolerance = webhook.DefaultTolerance
+)
+
+// WebhookHandler handles incoming Stripe webhook events
+type WebhookHandler struct {
+	webhookSecret string
+	tolerance     time.Duration
+	processor     *webhooks.Processor
+	replayToken   string
+}
+
+// NewWebhookHandler creates a new webhook handler that verifies events with the given
+// signing secret and hands them to the processor
+func NewWebhookHandler(webhookSecret string, processor *webhooks.Processor) *WebhookHandler {
+	return &WebhookHandler{
+		webhookSecret: webhookSecret,
+		tolerance:     DefaultWebhookTolerance,
+		processor:     processor,
+	}
+}
+
+// SetReplayToken sets the bearer token that authorizes replaying failed events.
+// Replay over HTTP stays disabled until a token is set.
+func (h *WebhookHandler) SetReplayToken(token string) {
+	h.replayToken = token
+}
+
+// HandleStripeWebhook verifies, parses and dispatches a Stripe webhook event
+func (h *WebhookHandler) HandleStripeWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.webhookSecret == "" {
+		log.Printf("Webhook rejected - STRIPE_WEBHOOK_SECRET is not configured")
+		writeError(w, http.StatusServiceUnavailable, "Webhook endpoint is not configured")
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxWebhookPayloadBytes))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, "Webhook payload could not be read")
+		return
+	}
+
+	event, err := webhook.ConstructEventWithOptions(payload, r.Header.Get("Stripe-Signature"), h.webhookSecret, webhook.ConstructEventOptions{
+		Tolerance: h.tolerance,
+		// Events are rendered with the account's API version, which may differ from the library's
+		IgnoreAPIVersionMismatch: true,
+	})
+	if err != nil {
+		log.Printf("Webhook rejected - Error: %v, RemoteAddr: %s", err, r.RemoteAddr)
+		writeError(w, http.StatusBadRequest, webhookVerificationMessage(err))
+		return
+	}
+
+	duplicate, err := h.processor.Process(r.Context(), event, payload)
+	if errors.Is(err, webhooks.ErrEventInProgress) {
+		writeError(w, http.StatusConflict, "Webhook event is already being processed")
+		return
+	}
+	if err != nil {
+		log.Printf("Webhook processing error - EventID: %s, Type: %s, Error: %v", event.ID, event.Type, err)
+		writeError(w, http.StatusInternalServerError, "Failed to process webhook event")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"received":  true,
+		"event_id":  event.ID,
+		"duplicate": duplicate,
+	})
+}
+
+// ReplayFailedEvents re-dispatches webhook events whose handlers previously failed.
+// Handlers have side effects, so the request must carry the replay token as a bearer token.
+func (h *WebhookHandler) ReplayFailedEvents(w http.ResponseWriter, r *http.Request) {
+	if h.replayToken == "" {
+		writeError(w, http.StatusServiceUnavailable, "Webhook replay is not configured")
+		return
+	}
+
+	expected := "Bearer " + h.replayToken
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(expected)) != 1 {
+		log.Printf("Webhook replay rejected - RemoteAddr: %s", r.RemoteAddr)
+		writeError(w, http.StatusUnauthorized, "Invalid or missing replay token")
+		return
+	}
+
+	replayed, err := h.processor.ReplayFailed(r.Context())
+	if err != nil {
+		log.Printf("Webhook replay error - Replayed: %d, Error: %v", replayed, err)
+		writeJSON(w, http.StatusInternalServerError, map[string]interface{}{
+			"error":    "Failed to replay some webhook events",
+			"replayed": replayed,
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"replayed": replayed,
+	})
+}
+
+// webhookVerificationMessage maps signature verification errors to client-facing messages
+func webhookVerificationMessage(err error) string {
+	switch {
+	case errors.Is(err, webhook.ErrNotSigned):
+		return "Missing Stripe-Signature header"
+	case errors.Is(err, webhook.ErrInvalidHeader):
+		return "Invalid Stripe-Signature header"
+	case errors.Is(err, webhook.ErrTooOld):
+		return "Webhook timestamp outside tolerance"
+	case errors.Is(err, webhook.ErrNoValidSignature):
+		return "Invalid webhook signature"
+	default:
+		return "Invalid webhook payload"
+	}
+}
@@ -0,0 +1,346 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"stripe-service/internal/webhooks"
+
+	"github.com/stripe/stripe-go/v76"
+	"github.com/stripe/stripe-go/v76/webhook"
+)
+
+const testWebhookSecret = "whsec_test_secret"
+
+func newTestProcessor() *webhooks.Processor {
+	dispatcher := webhooks.NewDispatcher()
+	webhooks.RegisterLoggingHandlers(dispatcher)
+	return webhooks.NewProcessor(dispatcher, webhooks.NewMemoryStore())
+}
+
+func newSignedWebhookRequest(t *testing.T, payload []byte, secret string, timestamp time.Time) *http.Request {
+	t.Helper()
+
+	signed := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{
+		Payload:   payload,
+		Secret:    secret,
+		Timestamp: timestamp,
+	})
+
+	req := httptest.NewRequest("POST", "/api/v1/webhooks/stripe", bytes.NewReader(payload))
+	req.Header.Set("Stripe-Signature", signed.Header)
+	return req
+}
+
+func testEventPayload(eventType string, object map[string]interface{}) []byte {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"id":          "evt_test123",
+		"object":      "event",
+		"api_version": "2020-08-27",
+		"type":        eventType,
+		"data": map[string]interface{}{
+			"object": object,
+		},
+	})
+	return payload
+}
+
+func TestNewWebhookHandler(t *testing.T) {
+	handler := NewWebhookHandler(testWebhookSecret, newTestProcessor())
+
+	if handler == nil {
+		t.Fatal("Expected handler to be created, got nil")
+	}
+	if handler.webhookSecret != testWebhookSecret {
+		t.Errorf("Expected webhook secret to be set, got %q", handler.webhookSecret)
+	}
+	if handler.tolerance != DefaultWebhookTolerance {
+		t.Errorf("Expected tolerance %v, got %v", DefaultWebhookTolerance, handler.tolerance)
+	}
+}
+
+func TestWebhookHandler_HandleStripeWebhook(t *testing.T) {
+	paymentIntentPayload := testEventPayload("payment_intent.succeeded", map[string]interface{}{
+		"id":       "pi_test123",
+		"object":   "payment_intent",
+		"amount":   1000,
+		"currency": "usd",
+		"status":   "succeeded",
+	})
+
+	tests := []struct {
+		name           string
+		secret         string
+		buildRequest   func(t *testing.T) *http.Request
+		expectedStatus int
+	}{
+		{
+			name:   "valid payment intent event",
+			secret: testWebhookSecret,
+			buildRequest: func(t *testing.T) *http.Request {
+				return newSignedWebhookRequest(t, paymentIntentPayload, testWebhookSecret, time.Now())
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "valid subscription event",
+			secret: testWebhookSecret,
+			buildRequest: func(t *testing.T) *http.Request {
+				payload := testEventPayload("customer.subscription.updated", map[string]interface{}{
+					"id":     "sub_test123",
+					"object": "subscription",
+					"status": "active",
+				})
+				return newSignedWebhookRequest(t, payload, testWebhookSecret, time.Now())
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "valid invoice event",
+			secret: testWebhookSecret,
+			buildRequest: func(t *testing.T) *http.Request {
+				payload := testEventPayload("invoice.payment_failed", map[string]interface{}{
+					"id":         "in_test123",
+					"object":     "invoice",
+					"amount_due": 2000,
+					"currency":   "usd",
+				})
+				return newSignedWebhookRequest(t, payload, testWebhookSecret, time.Now())
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "valid dispute event",
+			secret: testWebhookSecret,
+			buildRequest: func(t *testing.T) *http.Request {
+				payload := testEventPayload("charge.dispute.created", map[string]interface{}{
+					"id":       "dp_test123",
+					"object":   "dispute",
+					"amount":   1000,
+					"currency": "usd",
+					"status":   "needs_response",
+					"evidence_details": map[string]interface{}{
+						"due_by": 1700000000,
+					},
+				})
+				return newSignedWebhookRequest(t, payload, testWebhookSecret, time.Now())
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "unhandled event type",
+			secret: testWebhookSecret,
+			buildRequest: func(t *testing.T) *http.Request {
+				payload := testEventPayload("product.created", map[string]interface{}{
+					"id":     "prod_test123",
+					"object": "product",
+				})
+				return newSignedWebhookRequest(t, payload, testWebhookSecret, time.Now())
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "missing signature header",
+			secret: testWebhookSecret,
+			buildRequest: func(t *testing.T) *http.Request {
+				return httptest.NewRequest("POST", "/api/v1/webhooks/stripe", bytes.NewReader(paymentIntentPayload))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "signature from wrong secret",
+			secret: testWebhookSecret,
+			buildRequest: func(t *testing.T) *http.Request {
+				return newSignedWebhookRequest(t, paymentIntentPayload, "whsec_other_secret", time.Now())
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "stale timestamp",
+			secret: testWebhookSecret,
+			buildRequest: func(t *testing.T) *http.Request {
+				return newSignedWebhookRequest(t, paymentIntentPayload, testWebhookSecret, time.Now().Add(-10*time.Minute))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "undecodable event object",
+			secret: testWebhookSecret,
+			buildRequest: func(t *testing.T) *http.Request {
+				payload := testEventPayload("payment_intent.succeeded", map[string]interface{}{
+					"id":     "pi_test123",
+					"amount": "not-a-number",
+				})
+				return newSignedWebhookRequest(t, payload, testWebhookSecret, time.Now())
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:   "secret not configured",
+			secret: "",
+			buildRequest: func(t *testing.T) *http.Request {
+				return newSignedWebhookRequest(t, paymentIntentPayload, testWebhookSecret, time.Now())
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewWebhookHandler(tt.secret, newTestProcessor())
+			rr := httptest.NewRecorder()
+
+			handler.HandleStripeWebhook(rr, tt.buildRequest(t))
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d (body: %s)", tt.expectedStatus, status, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_PayloadTooLarge(t *testing.T) {
+	handler := NewWebhookHandler(testWebhookSecret, newTestProcessor())
+	payload := bytes.Repeat([]byte("a"), MaxWebhookPayloadBytes+1)
+
+	rr := httptest.NewRecorder()
+	handler.HandleStripeWebhook(rr, newSignedWebhookRequest(t, payload, testWebhookSecret, time.Now()))
+
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status code %d, got %d", http.StatusRequestEntityTooLarge, rr.Code)
+	}
+}
+
+func TestWebhookHandler_DuplicateEvent(t *testing.T) {
+	dispatcher := webhooks.NewDispatcher()
+	calls := 0
+	dispatcher.Register(stripe.EventTypePaymentIntentSucceeded, func(ctx context.Context, event stripe.Event) error {
+		calls++
+		return nil
+	})
+	handler := NewWebhookHandler(testWebhookSecret, webhooks.NewProcessor(dispatcher, webhooks.NewMemoryStore()))
+
+	payload := testEventPayload("payment_intent.succeeded", map[string]interface{}{
+		"id":     "pi_test123",
+		"object": "payment_intent",
+	})
+
+	for i, expectDuplicate := range []bool{false, true} {
+		rr := httptest.NewRecorder()
+		handler.HandleStripeWebhook(rr, newSignedWebhookRequest(t, payload, testWebhookSecret, time.Now()))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Delivery %d: expected status code %d, got %d", i+1, http.StatusOK, rr.Code)
+		}
+
+		var response map[string]interface{}
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Error unmarshaling response: %v", err)
+		}
+		if response["duplicate"] != expectDuplicate {
+			t.Errorf("Delivery %d: expected duplicate=%v, got %v", i+1, expectDuplicate, response["duplicate"])
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected handler to run once, ran %d times", calls)
+	}
+}
+
+func TestWebhookHandler_ReplayFailedEvents(t *testing.T) {
+	dispatcher := webhooks.NewDispatcher()
+	fail := true
+	dispatcher.Register(stripe.EventTypeInvoicePaymentFailed, func(ctx context.Context, event stripe.Event) error {
+		if fail {
+			return errors.New("downstream unavailable")
+		}
+		return nil
+	})
+	handler := NewWebhookHandler(testWebhookSecret, webhooks.NewProcessor(dispatcher, webhooks.NewMemoryStore()))
+	handler.SetReplayToken("replay-secret")
+
+	payload := testEventPayload("invoice.payment_failed", map[string]interface{}{
+		"id":     "in_test123",
+		"object": "invoice",
+	})
+	rr := httptest.NewRecorder()
+	handler.HandleStripeWebhook(rr, newSignedWebhookRequest(t, payload, testWebhookSecret, time.Now()))
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected status code %d, got %d", http.StatusInternalServerError, rr.Code)
+	}
+
+	fail = false
+	rr = httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/v1/webhooks/stripe/replay", nil)
+	req.Header.Set("Authorization", "Bearer replay-secret")
+	handler.ReplayFailedEvents(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Error unmarshaling response: %v", err)
+	}
+	if response["replayed"] != float64(1) {
+		t.Errorf("Expected 1 replayed event, got %v", response["replayed"])
+	}
+}
+
+func TestWebhookHandler_ReplayFailedEvents_Authorization(t *testing.T) {
+	tests := []struct {
+		name           string
+		replayToken    string
+		authorization  string
+		expectedStatus int
+	}{
+		{
+			name:           "replay not configured",
+			authorization:  "Bearer replay-secret",
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "missing token",
+			replayToken:    "replay-secret",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "wrong token",
+			replayToken:    "replay-secret",
+			authorization:  "Bearer guess",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "valid token",
+			replayToken:    "replay-secret",
+			authorization:  "Bearer replay-secret",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewWebhookHandler(testWebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
+			handler.SetReplayToken(tt.replayToken)
+
+			req := httptest.NewRequest("POST", "/api/v1/webhooks/stripe/replay", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ReplayFailedEvents(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package idempotency
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultTTL is how long a stored response can be replayed, matching Stripe's 24h window
+const DefaultTTL = 24 * time.Hour
+
+var (
+	// ErrKeyReused is returned when a key is reused with a different request body
+	ErrKeyReused = errors.New("idempotency key reused with a different request")
+	// ErrRequestInProgress is returned when the original request for a key has not finished
+	ErrRequestInProgress = errors.New("request with this idempotency key is in progress")
+)
+
+// Response is a stored HTTP response that can be replayed
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+type entry struct {
+	requestHash string
+	response    *Response
+	expiresAt   time.Time
+}
+
+// Store keeps the first response for each idempotency key in memory
+type Store struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[string]*entry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewStore creates a store whose responses expire after ttl
+func NewStore(ttl time.Duration) *Store {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Store{
+		ttl:     ttl,
+		entries: make(map[string]*entry),
+		now:     time.Now,
+	}
+}
+
+// Begin reserves key for a request with the given body hash. It returns the stored
+// response when the key has already completed with the same hash, ErrKeyReused when
+// the hash differs, ErrRequestInProgress when the first request is still running,
+// and (nil, nil) when the caller should execute the request and call Complete or Release.
+func (s *Store) Begin(key, requestHash string) (*Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	if e, ok := s.entries[key]; ok && now.Before(e.expiresAt) {
+		if e.requestHash != requestHash {
+			return nil, ErrKeyReused
+		}
+		if e.response == nil {
+			return nil, ErrRequestInProgress
+		}
+		return e.response, nil
+	}
+
+	s.entries[key] = &entry{
+		requestHash: requestHash,
+		expiresAt:   now.Add(s.ttl),
+	}
+	return nil, nil
+}
+
+// Complete stores the response for a key reserved with Begin
+func (s *Store) Complete(key string, response *Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok {
+		e.response = response
+	}
+}
+
+// Release drops a reservation so the request can be retried with the same key
+func (s *Store) Release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+}
+
+// sweep evicts expired entries at most once a minute; callers must hold s.mu
+func (s *Store) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+
+	for key, e := range s.entries {
+		if !now.Before(e.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
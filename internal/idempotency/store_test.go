@@ -0,0 +1,68 @@
+package idempotency
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStore_DefaultTTL(t *testing.T) {
+	store := NewStore(0)
+	assert.Equal(t, DefaultTTL, store.ttl)
+}
+
+func TestStore_ReplaysCompletedResponse(t *testing.T) {
+	store := NewStore(time.Hour)
+
+	response, err := store.Begin("key-1", "hash-a")
+	require.NoError(t, err)
+	assert.Nil(t, response, "Expected first request to proceed")
+
+	_, err = store.Begin("key-1", "hash-a")
+	assert.ErrorIs(t, err, ErrRequestInProgress)
+
+	stored := &Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       []byte(`{"id":"cus_123"}`),
+	}
+	store.Complete("key-1", stored)
+
+	response, err = store.Begin("key-1", "hash-a")
+	require.NoError(t, err)
+	assert.Equal(t, stored, response)
+
+	_, err = store.Begin("key-1", "hash-b")
+	assert.ErrorIs(t, err, ErrKeyReused)
+}
+
+func TestStore_Release(t *testing.T) {
+	store := NewStore(time.Hour)
+
+	_, err := store.Begin("key-1", "hash-a")
+	require.NoError(t, err)
+	store.Release("key-1")
+
+	response, err := store.Begin("key-1", "hash-b")
+	require.NoError(t, err)
+	assert.Nil(t, response, "Expected released key to be reusable")
+}
+
+func TestStore_Expiry(t *testing.T) {
+	store := NewStore(time.Hour)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	_, err := store.Begin("key-1", "hash-a")
+	require.NoError(t, err)
+	store.Complete("key-1", &Response{StatusCode: http.StatusOK})
+
+	now = now.Add(2 * time.Hour)
+
+	response, err := store.Begin("key-1", "hash-b")
+	require.NoError(t, err)
+	assert.Nil(t, response, "Expected expired key to be reusable")
+}
@@ -0,0 +1,90 @@
+package models
+
+import "time"
+
+// CheckoutSession represents a Stripe-hosted Checkout page
+type CheckoutSession struct {
+	ID              string            `json:"id"`
+	URL             string            `json:"url,omitempty"`
+	Mode            string            `json:"mode"`
+	Status          string            `json:"status"`
+	PaymentStatus   string            `json:"payment_status"`
+	CustomerID      string            `json:"customer_id,omitempty"`
+	CustomerEmail   string            `json:"customer_email,omitempty"`
+	Currency        string            `json:"currency,omitempty"`
+	AmountSubtotal  int64             `json:"amount_subtotal"`
+	AmountTotal     int64             `json:"amount_total"`
+	PaymentIntentID string            `json:"payment_intent_id,omitempty"`
+	SubscriptionID  string            `json:"subscription_id,omitempty"`
+	SetupIntentID   string            `json:"setup_intent_id,omitempty"`
+	SuccessURL      string            `json:"success_url,omitempty"`
+	CancelURL       string            `json:"cancel_url,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	ExpiresAt       time.Time         `json:"expires_at"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+// CreateCheckoutSessionRequest represents the request to create a Checkout session.
+// Payment and subscription sessions need at least one line item; setup sessions take none
+// and require a currency.
+type CreateCheckoutSessionRequest struct {
+	Mode                string             `json:"mode" validate:"required,oneof=payment subscription setup"`
+	LineItems           []CheckoutLineItem `json:"line_items,omitempty" validate:"dive"`
+	SuccessURL          string             `json:"success_url" validate:"required,url"`
+	CancelURL           string             `json:"cancel_url,omitempty" validate:"omitempty,url"`
+	CustomerID          string             `json:"customer_id,omitempty"`
+	CustomerEmail       string             `json:"customer_email,omitempty" validate:"omitempty,email,excluded_with=CustomerID"`
+	Currency            string             `json:"currency,omitempty" validate:"omitempty,len=3"`
+	AllowPromotionCodes bool               `json:"allow_promotion_codes,omitempty"`
+	Metadata            map[string]string  `json:"metadata,omitempty"`
+}
+
+// CheckoutLineItem is a single line of a Checkout session, priced either by an existing
+// price ID or by ad-hoc price data
+type CheckoutLineItem struct {
+	PriceID   string             `json:"price_id,omitempty" validate:"required_without=PriceData,excluded_with=PriceData"`
+	PriceData *CheckoutPriceData `json:"price_data,omitempty"`
+	Quantity  int64              `json:"quantity" validate:"required,min=1"`
+}
+
+// CheckoutPriceData describes an inline price for a Checkout line item
+type CheckoutPriceData struct {
+	Currency    string              `json:"currency" validate:"required,len=3"`
+	UnitAmount  int64               `json:"unit_amount" validate:"min=0"`
+	ProductID   string              `json:"product_id,omitempty" validate:"required_without=ProductName,excluded_with=ProductName"`
+	ProductName string              `json:"product_name,omitempty"`
+	Recurring   *RecurringPriceData `json:"recurring,omitempty"`
+}
+
+// RecurringPriceData describes the billing interval of an inline recurring price
+type RecurringPriceData struct {
+	Interval      string `json:"interval" validate:"required,oneof=day week month year"`
+	IntervalCount int64  `json:"interval_count,omitempty" validate:"omitempty,min=1"`
+}
+
+// CheckoutLineItemSummary represents a purchased line item of a Checkout session
+type CheckoutLineItemSummary struct {
+	ID             string `json:"id"`
+	Description    string `json:"description"`
+	PriceID        string `json:"price_id,omitempty"`
+	Quantity       int64  `json:"quantity"`
+	Currency       string `json:"currency"`
+	AmountSubtotal int64  `json:"amount_subtotal"`
+	AmountDiscount int64  `json:"amount_discount"`
+	AmountTax      int64  `json:"amount_tax"`
+	AmountTotal    int64  `json:"amount_total"`
+}
+
+// ListCheckoutLineItemsRequest represents the request to list a Checkout session's line items
+type ListCheckoutLineItemsRequest struct {
+	SessionID string `json:"session_id" validate:"required"`
+	Limit     int64  `json:"limit,omitempty"`
+	Cursor    string `json:"cursor,omitempty"`
+}
+
+// ListCheckoutLineItemsResponse represents the response when listing Checkout line items
+type ListCheckoutLineItemsResponse struct {
+	LineItems  []CheckoutLineItemSummary `json:"line_items"`
+	HasMore    bool                      `json:"has_more"`
+	NextCursor string                    `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestCreateCheckoutSessionRequest_Validation(t *testing.T) {
+	validator := validator.New()
+
+	tests := []struct {
+		name    string
+		request CreateCheckoutSessionRequest
+		wantErr bool
+	}{
+		{
+			name: "payment with existing price",
+			request: CreateCheckoutSessionRequest{
+				Mode:       "payment",
+				LineItems:  []CheckoutLineItem{{PriceID: "price_123", Quantity: 1}},
+				SuccessURL: "https://example.com/success",
+				CancelURL:  "https://example.com/cancel",
+			},
+			wantErr: false,
+		},
+		{
+			name: "subscription with inline recurring price",
+			request: CreateCheckoutSessionRequest{
+				Mode: "subscription",
+				LineItems: []CheckoutLineItem{{
+					PriceData: &CheckoutPriceData{
+						Currency:    "usd",
+						UnitAmount:  1500,
+						ProductName: "Pro plan",
+						Recurring:   &RecurringPriceData{Interval: "month"},
+					},
+					Quantity: 1,
+				}},
+				SuccessURL:    "https://example.com/success",
+				CustomerEmail: "jane@example.com",
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing mode",
+			request: CreateCheckoutSessionRequest{
+				SuccessURL: "https://example.com/success",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid success url",
+			request: CreateCheckoutSessionRequest{
+				Mode:       "setup",
+				SuccessURL: "not a url",
+			},
+			wantErr: true,
+		},
+		{
+			name: "line item with both price and price data",
+			request: CreateCheckoutSessionRequest{
+				Mode: "payment",
+				LineItems: []CheckoutLineItem{{
+					PriceID:   "price_123",
+					PriceData: &CheckoutPriceData{Currency: "usd", UnitAmount: 100, ProductID: "prod_123"},
+					Quantity:  1,
+				}},
+				SuccessURL: "https://example.com/success",
+			},
+			wantErr: true,
+		},
+		{
+			name: "line item without price",
+			request: CreateCheckoutSessionRequest{
+				Mode:       "payment",
+				LineItems:  []CheckoutLineItem{{Quantity: 1}},
+				SuccessURL: "https://example.com/success",
+			},
+			wantErr: true,
+		},
+		{
+			name: "price data without product",
+			request: CreateCheckoutSessionRequest{
+				Mode: "payment",
+				LineItems: []CheckoutLineItem{{
+					PriceData: &CheckoutPriceData{Currency: "usd", UnitAmount: 100},
+					Quantity:  1,
+				}},
+				SuccessURL: "https://example.com/success",
+			},
+			wantErr: true,
+		},
+		{
+			name: "customer and customer email together",
+			request: CreateCheckoutSessionRequest{
+				Mode:          "setup",
+				SuccessURL:    "https://example.com/success",
+				CustomerID:    "cus_123",
+				CustomerEmail: "jane@example.com",
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero quantity",
+			request: CreateCheckoutSessionRequest{
+				Mode:       "payment",
+				LineItems:  []CheckoutLineItem{{PriceID: "price_123"}},
+				SuccessURL: "https://example.com/success",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Struct(tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateCheckoutSessionRequest validation = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,14 +4,15 @@ import "time"
 
 // Customer represents a customer in the system
 type Customer struct {
-	ID          string            `json:"id"`
-	Email       string            `json:"email"`
-	Name        string            `json:"name"`
-	Phone       string            `json:"phone,omitempty"`
-	Description string            `json:"description,omitempty"`
-	Metadata    map[string]string `json:"metadata,omitempty"`
-	CreatedAt   time.Time         `json:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
+	ID                     string            `json:"id"`
+	Email                  string            `json:"email"`
+	Name                   string            `json:"name"`
+	Phone                  string            `json:"phone,omitempty"`
+	Description            string            `json:"description,omitempty"`
+	Metadata               map[string]string `json:"metadata,omitempty"`
+	DefaultPaymentMethodID string            `json:"default_payment_method_id,omitempty"`
+	CreatedAt              time.Time         `json:"created_at"`
+	UpdatedAt              time.Time         `json:"updated_at"`
 }
 
 // CreateCustomerRequest represents the request to create a customer
@@ -23,24 +24,49 @@ type CreateCustomerRequest struct {
 	Metadata    map[string]string `json:"metadata,omitempty"`
 }
 
-// UpdateCustomerRequest represents the request to update a customer
+// UpdateCustomerRequest represents the request to partially update a customer.
+// Only fields present in the request are changed; a present empty string clears
+// the field.
 type UpdateCustomerRequest struct {
-	Email       string            `json:"email,omitempty" validate:"omitempty,email"`
-	Name        string            `json:"name,omitempty"`
-	Phone       string            `json:"phone,omitempty"`
-	Description string            `json:"description,omitempty"`
+	Email       *string           `json:"email,omitempty" validate:"omitempty,email"`
+	Name        *string           `json:"name,omitempty"`
+	Phone       *string           `json:"phone,omitempty"`
+	Description *string           `json:"description,omitempty"`
 	Metadata    map[string]string `json:"metadata,omitempty"`
 }
 
-// ListCustomersRequest represents the request to list customers
+// DeletedCustomer represents the response when a customer is deleted
+type DeletedCustomer struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// ListCustomersRequest represents the request to list customers.
+// Cursor pages forward (older customers) and EndingBefore pages backward (newer customers).
+//
+// Email and the created range are applied as list filters. Setting Query, Metadata or
+// Page switches to Stripe's search API, where Query uses Stripe's search syntax
+// (e.g. `name~'jane'`), the other filters are ANDed onto it and results are paged with
+// Page instead of the cursors.
 type ListCustomersRequest struct {
-	Limit  int64  `json:"limit,omitempty"`
-	Cursor string `json:"cursor,omitempty"`
+	Limit        int64             `json:"limit,omitempty"`
+	Cursor       string            `json:"cursor,omitempty"`
+	EndingBefore string            `json:"ending_before,omitempty"`
+	Email        string            `json:"email,omitempty"`
+	CreatedGte   int64             `json:"created_gte,omitempty"`
+	CreatedLte   int64             `json:"created_lte,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	Query        string            `json:"query,omitempty"`
+	Page         string            `json:"page,omitempty"`
 }
 
-// ListCustomersResponse represents the response when listing customers
+// ListCustomersResponse represents the response when listing customers.
+// NextCursor is passed back as cursor and PrevCursor as ending_before to fetch adjacent
+// pages; search results are paged by passing NextPage back as page.
 type ListCustomersResponse struct {
 	Customers  []Customer `json:"customers"`
 	HasMore    bool       `json:"has_more"`
 	NextCursor string     `json:"next_cursor,omitempty"`
+	PrevCursor string     `json:"prev_cursor,omitempty"`
+	NextPage   string     `json:"next_page,omitempty"`
 }
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -68,6 +69,66 @@ func TestCreateCustomerRequest_Validation(t *testing.T) {
 	}
 }
 
+func TestUpdateCustomerRequest_Validation(t *testing.T) {
+	validator := validator.New()
+	validEmail := "new@example.com"
+	invalidEmail := "invalid-email"
+	emptyName := ""
+
+	tests := []struct {
+		name    string
+		request UpdateCustomerRequest
+		wantErr bool
+	}{
+		{
+			name:    "empty update",
+			request: UpdateCustomerRequest{},
+			wantErr: false,
+		},
+		{
+			name:    "valid email",
+			request: UpdateCustomerRequest{Email: &validEmail},
+			wantErr: false,
+		},
+		{
+			name:    "invalid email format",
+			request: UpdateCustomerRequest{Email: &invalidEmail},
+			wantErr: true,
+		},
+		{
+			name: "clear name and unset metadata key",
+			request: UpdateCustomerRequest{
+				Name:     &emptyName,
+				Metadata: map[string]string{"legacy": ""},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Struct(tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateCustomerRequest validation = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateCustomerRequest_PartialJSON(t *testing.T) {
+	var req UpdateCustomerRequest
+	if err := json.Unmarshal([]byte(`{"phone":""}`), &req); err != nil {
+		t.Fatalf("Error unmarshaling request: %v", err)
+	}
+
+	if req.Phone == nil || *req.Phone != "" {
+		t.Errorf("Expected Phone to be present and empty, got %v", req.Phone)
+	}
+	if req.Name != nil || req.Email != nil || req.Description != nil {
+		t.Error("Expected fields absent from the request to be nil")
+	}
+}
+
 func TestCustomer_Structure(t *testing.T) {
 	now := time.Now()
 	customer := Customer{
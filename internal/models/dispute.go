@@ -0,0 +1,78 @@
+package models
+
+import "time"
+
+// Dispute represents a chargeback or inquiry raised against a payment
+type Dispute struct {
+	ID                 string            `json:"id"`
+	Amount             int64             `json:"amount"`
+	Currency           string            `json:"currency"`
+	Status             string            `json:"status"`
+	Reason             string            `json:"reason"`
+	ChargeID           string            `json:"charge_id,omitempty"`
+	PaymentIntentID    string            `json:"payment_intent_id,omitempty"`
+	IsChargeRefundable bool              `json:"is_charge_refundable"`
+	EvidenceDueBy      *time.Time        `json:"evidence_due_by,omitempty"`
+	HasEvidence        bool              `json:"has_evidence"`
+	PastDue            bool              `json:"past_due"`
+	SubmissionCount    int64             `json:"submission_count"`
+	Evidence           *DisputeEvidence  `json:"evidence,omitempty"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+	CreatedAt          time.Time         `json:"created_at"`
+}
+
+// DisputeEvidence holds the evidence submitted for a dispute. Text fields carry free-form
+// values; document fields carry the IDs of files previously uploaded to Stripe (file_...).
+type DisputeEvidence struct {
+	AccessActivityLog            string `json:"access_activity_log,omitempty"`
+	BillingAddress               string `json:"billing_address,omitempty"`
+	CancellationPolicy           string `json:"cancellation_policy,omitempty"`
+	CancellationPolicyDisclosure string `json:"cancellation_policy_disclosure,omitempty"`
+	CancellationRebuttal         string `json:"cancellation_rebuttal,omitempty"`
+	CustomerCommunication        string `json:"customer_communication,omitempty"`
+	CustomerEmailAddress         string `json:"customer_email_address,omitempty" validate:"omitempty,email"`
+	CustomerName                 string `json:"customer_name,omitempty"`
+	CustomerPurchaseIP           string `json:"customer_purchase_ip,omitempty" validate:"omitempty,ip"`
+	CustomerSignature            string `json:"customer_signature,omitempty"`
+	DuplicateChargeDocumentation string `json:"duplicate_charge_documentation,omitempty"`
+	DuplicateChargeExplanation   string `json:"duplicate_charge_explanation,omitempty"`
+	DuplicateChargeID            string `json:"duplicate_charge_id,omitempty"`
+	ProductDescription           string `json:"product_description,omitempty"`
+	Receipt                      string `json:"receipt,omitempty"`
+	RefundPolicy                 string `json:"refund_policy,omitempty"`
+	RefundPolicyDisclosure       string `json:"refund_policy_disclosure,omitempty"`
+	RefundRefusalExplanation     string `json:"refund_refusal_explanation,omitempty"`
+	ServiceDate                  string `json:"service_date,omitempty"`
+	ServiceDocumentation         string `json:"service_documentation,omitempty"`
+	ShippingAddress              string `json:"shipping_address,omitempty"`
+	ShippingCarrier              string `json:"shipping_carrier,omitempty"`
+	ShippingDate                 string `json:"shipping_date,omitempty"`
+	ShippingDocumentation        string `json:"shipping_documentation,omitempty"`
+	ShippingTrackingNumber       string `json:"shipping_tracking_number,omitempty"`
+	UncategorizedFile            string `json:"uncategorized_file,omitempty"`
+	UncategorizedText            string `json:"uncategorized_text,omitempty"`
+}
+
+// SubmitDisputeEvidenceRequest represents the request to add evidence to a dispute.
+// Evidence is staged until Submit is true; once submitted it can no longer be changed.
+type SubmitDisputeEvidenceRequest struct {
+	Evidence DisputeEvidence   `json:"evidence"`
+	Submit   bool              `json:"submit"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// ListDisputesRequest represents the request to list disputes
+type ListDisputesRequest struct {
+	Limit           int64  `json:"limit,omitempty"`
+	Cursor          string `json:"cursor,omitempty"`
+	PaymentIntentID string `json:"payment_intent_id,omitempty"`
+	CreatedGte      int64  `json:"created_gte,omitempty"`
+	CreatedLte      int64  `json:"created_lte,omitempty"`
+}
+
+// ListDisputesResponse represents the response when listing disputes
+type ListDisputesResponse struct {
+	Disputes   []Dispute `json:"disputes"`
+	HasMore    bool      `json:"has_more"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
@@ -14,19 +14,30 @@ type PaymentIntent struct {
 	ClientSecret       string            `json:"client_secret,omitempty"`
 	PaymentMethodID    string            `json:"payment_method_id,omitempty"`
 	ConfirmationMethod string            `json:"confirmation_method,omitempty"`
+	CaptureMethod      string            `json:"capture_method,omitempty"`
+	AmountCapturable   int64             `json:"amount_capturable"`
+	AmountReceived     int64             `json:"amount_received"`
+	CaptureBefore      *time.Time        `json:"capture_before,omitempty"`
+	CancellationReason string            `json:"cancellation_reason,omitempty"`
+	CanceledAt         *time.Time        `json:"canceled_at,omitempty"`
 	CreatedAt          time.Time         `json:"created_at"`
 	UpdatedAt          time.Time         `json:"updated_at"`
 }
 
-// CreatePaymentIntentRequest represents the request to create a payment intent
+// CreatePaymentIntentRequest represents the request to create a payment intent.
+// Instead of Amount, PriceID charges Quantity units of a one-time price in Currency,
+// using the price's currency_options amount when Currency is not its default currency.
 type CreatePaymentIntentRequest struct {
-	Amount             int64             `json:"amount" validate:"required,min=1"`
+	Amount             int64             `json:"amount" validate:"required_without=PriceID,excluded_with=PriceID,omitempty,min=1"`
 	Currency           string            `json:"currency" validate:"required,len=3"`
+	PriceID            string            `json:"price_id,omitempty"`
+	Quantity           int64             `json:"quantity,omitempty" validate:"omitempty,min=1"`
 	CustomerID         string            `json:"customer_id,omitempty"`
 	Description        string            `json:"description,omitempty"`
 	Metadata           map[string]string `json:"metadata,omitempty"`
 	PaymentMethodID    string            `json:"payment_method_id,omitempty"`
 	ConfirmationMethod string            `json:"confirmation_method,omitempty"`
+	CaptureMethod      string            `json:"capture_method,omitempty" validate:"omitempty,oneof=automatic automatic_async manual"`
 }
 
 // ConfirmPaymentIntentRequest represents the request to confirm a payment intent
@@ -34,3 +45,46 @@ type ConfirmPaymentIntentRequest struct {
 	PaymentMethodID string `json:"payment_method_id,omitempty"`
 	ReturnURL       string `json:"return_url,omitempty"`
 }
+
+// UpdatePaymentIntentRequest represents the request to partially update a payment intent.
+// Only fields present in the request are changed.
+type UpdatePaymentIntentRequest struct {
+	Amount          *int64            `json:"amount,omitempty" validate:"omitempty,min=1"`
+	Currency        *string           `json:"currency,omitempty" validate:"omitempty,len=3"`
+	CustomerID      *string           `json:"customer_id,omitempty"`
+	Description     *string           `json:"description,omitempty"`
+	PaymentMethodID *string           `json:"payment_method_id,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+}
+
+// CancelPaymentIntentRequest represents the request to cancel a payment intent
+type CancelPaymentIntentRequest struct {
+	CancellationReason string `json:"cancellation_reason,omitempty" validate:"omitempty,oneof=duplicate fraudulent requested_by_customer abandoned"`
+}
+
+// CapturePaymentIntentRequest represents the request to capture a payment intent created with
+// capture_method=manual. When AmountToCapture is omitted the full capturable amount is captured;
+// a smaller amount performs a partial capture and releases the remainder of the hold.
+type CapturePaymentIntentRequest struct {
+	AmountToCapture int64 `json:"amount_to_capture,omitempty" validate:"omitempty,min=1"`
+}
+
+// ListPaymentIntentsRequest represents the request to list payment intents.
+// Filtering by Status uses Stripe's search API, which is paged with Page instead of Cursor.
+type ListPaymentIntentsRequest struct {
+	Limit      int64  `json:"limit,omitempty"`
+	Cursor     string `json:"cursor,omitempty"`
+	CustomerID string `json:"customer_id,omitempty"`
+	Status     string `json:"status,omitempty" validate:"omitempty,oneof=requires_payment_method requires_confirmation requires_action processing requires_capture canceled succeeded"`
+	CreatedGte int64  `json:"created_gte,omitempty"`
+	CreatedLte int64  `json:"created_lte,omitempty"`
+	Page       string `json:"page,omitempty"`
+}
+
+// ListPaymentIntentsResponse represents the response when listing payment intents
+type ListPaymentIntentsResponse struct {
+	PaymentIntents []PaymentIntent `json:"payment_intents"`
+	HasMore        bool            `json:"has_more"`
+	NextCursor     string          `json:"next_cursor,omitempty"`
+	NextPage       string          `json:"next_page,omitempty"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// PaymentMethod represents a saved payment method such as a card
+type PaymentMethod struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type"`
+	CustomerID string            `json:"customer_id,omitempty"`
+	Card       *PaymentCard      `json:"card,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// PaymentCard holds the non-sensitive details of a card payment method
+type PaymentCard struct {
+	Brand      string `json:"brand"`
+	Last4      string `json:"last4"`
+	ExpMonth   int64  `json:"exp_month"`
+	ExpYear    int64  `json:"exp_year"`
+	Funding    string `json:"funding,omitempty"`
+	Country    string `json:"country,omitempty"`
+	WalletType string `json:"wallet_type,omitempty"`
+}
+
+// AttachPaymentMethodRequest represents the request to attach a payment method to a customer
+type AttachPaymentMethodRequest struct {
+	CustomerID   string `json:"customer_id" validate:"required"`
+	SetAsDefault bool   `json:"set_as_default,omitempty"`
+}
+
+// SetDefaultPaymentMethodRequest represents the request to set a customer's default payment method
+// for invoices and subscriptions
+type SetDefaultPaymentMethodRequest struct {
+	PaymentMethodID string `json:"payment_method_id" validate:"required"`
+}
+
+// ListPaymentMethodsRequest represents the request to list a customer's payment methods
+type ListPaymentMethodsRequest struct {
+	CustomerID string `json:"customer_id" validate:"required"`
+	Type       string `json:"type,omitempty"`
+	Limit      int64  `json:"limit,omitempty"`
+	Cursor     string `json:"cursor,omitempty"`
+}
+
+// ListPaymentMethodsResponse represents the response when listing payment methods
+type ListPaymentMethodsResponse struct {
+	PaymentMethods []PaymentMethod `json:"payment_methods"`
+	HasMore        bool            `json:"has_more"`
+	NextCursor     string          `json:"next_cursor,omitempty"`
+}
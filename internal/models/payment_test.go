@@ -53,6 +53,24 @@ func TestCreatePaymentIntentRequest_Validation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "priced by price ID",
+			request: CreatePaymentIntentRequest{
+				Currency: "eur",
+				PriceID:  "price_123",
+				Quantity: 2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "amount together with price ID",
+			request: CreatePaymentIntentRequest{
+				Amount:   1000,
+				Currency: "eur",
+				PriceID:  "price_123",
+			},
+			wantErr: true,
+		},
 		{
 			name: "with optional fields",
 			request: CreatePaymentIntentRequest{
@@ -116,6 +134,80 @@ func TestConfirmPaymentIntentRequest_Validation(t *testing.T) {
 	}
 }
 
+func TestUpdatePaymentIntentRequest_Validation(t *testing.T) {
+	validator := validator.New()
+	amount := int64(2000)
+	zero := int64(0)
+	currency := "eur"
+	badCurrency := "euro"
+
+	tests := []struct {
+		name    string
+		request UpdatePaymentIntentRequest
+		wantErr bool
+	}{
+		{
+			name:    "empty update",
+			request: UpdatePaymentIntentRequest{},
+			wantErr: false,
+		},
+		{
+			name:    "valid amount and currency",
+			request: UpdatePaymentIntentRequest{Amount: &amount, Currency: &currency},
+			wantErr: false,
+		},
+		{
+			name:    "zero amount",
+			request: UpdatePaymentIntentRequest{Amount: &zero},
+			wantErr: true,
+		},
+		{
+			name:    "invalid currency",
+			request: UpdatePaymentIntentRequest{Currency: &badCurrency},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Struct(tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdatePaymentIntentRequest validation = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCancelAndCapturePaymentIntentRequest_Validation(t *testing.T) {
+	validator := validator.New()
+
+	tests := []struct {
+		name    string
+		request interface{}
+		wantErr bool
+	}{
+		{"cancel without reason", CancelPaymentIntentRequest{}, false},
+		{"cancel with valid reason", CancelPaymentIntentRequest{CancellationReason: "fraudulent"}, false},
+		{"cancel with unknown reason", CancelPaymentIntentRequest{CancellationReason: "bored"}, true},
+		{"manual capture method", CreatePaymentIntentRequest{Amount: 1000, Currency: "usd", CaptureMethod: "manual"}, false},
+		{"unknown capture method", CreatePaymentIntentRequest{Amount: 1000, Currency: "usd", CaptureMethod: "later"}, true},
+		{"capture full amount", CapturePaymentIntentRequest{}, false},
+		{"capture partial amount", CapturePaymentIntentRequest{AmountToCapture: 500}, false},
+		{"capture negative amount", CapturePaymentIntentRequest{AmountToCapture: -1}, true},
+		{"list by status", ListPaymentIntentsRequest{Status: "requires_capture"}, false},
+		{"list by unknown status", ListPaymentIntentsRequest{Status: "paid"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Struct(tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%T validation = %v, wantErr %v", tt.request, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestPaymentIntent_Structure(t *testing.T) {
 	now := time.Now()
 	payment := PaymentIntent{
@@ -0,0 +1,88 @@
+package models
+
+import "time"
+
+// PortalSession represents a short-lived link to the Stripe customer portal
+type PortalSession struct {
+	ID              string    `json:"id"`
+	URL             string    `json:"url"`
+	CustomerID      string    `json:"customer_id"`
+	ReturnURL       string    `json:"return_url,omitempty"`
+	ConfigurationID string    `json:"configuration_id,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// CreatePortalSessionRequest represents the request to open the customer portal.
+// The account's default portal configuration is used when ConfigurationID is omitted.
+type CreatePortalSessionRequest struct {
+	ReturnURL       string `json:"return_url" validate:"required,url"`
+	ConfigurationID string `json:"configuration_id,omitempty"`
+}
+
+// PortalConfiguration controls which features the customer portal offers
+type PortalConfiguration struct {
+	ID                string            `json:"id"`
+	Active            bool              `json:"active"`
+	IsDefault         bool              `json:"is_default"`
+	Headline          string            `json:"headline,omitempty"`
+	PrivacyPolicyURL  string            `json:"privacy_policy_url,omitempty"`
+	TermsOfServiceURL string            `json:"terms_of_service_url,omitempty"`
+	DefaultReturnURL  string            `json:"default_return_url,omitempty"`
+	Features          PortalFeatures    `json:"features"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+}
+
+// PortalFeatures lists the customer portal features and their settings
+type PortalFeatures struct {
+	InvoiceHistory      bool                     `json:"invoice_history"`
+	PaymentMethodUpdate bool                     `json:"payment_method_update"`
+	SubscriptionCancel  PortalSubscriptionCancel `json:"subscription_cancel"`
+	SubscriptionUpdate  PortalSubscriptionUpdate `json:"subscription_update"`
+}
+
+// PortalSubscriptionCancel configures self-service subscription cancellation
+type PortalSubscriptionCancel struct {
+	Enabled           bool   `json:"enabled"`
+	Mode              string `json:"mode,omitempty" validate:"omitempty,oneof=at_period_end immediately"`
+	ProrationBehavior string `json:"proration_behavior,omitempty" validate:"omitempty,oneof=always_invoice create_prorations none"`
+}
+
+// PortalSubscriptionUpdate configures self-service plan switching
+type PortalSubscriptionUpdate struct {
+	Enabled               bool            `json:"enabled"`
+	DefaultAllowedUpdates []string        `json:"default_allowed_updates,omitempty" validate:"dive,oneof=price quantity promotion_code"`
+	Products              []PortalProduct `json:"products,omitempty" validate:"dive"`
+	ProrationBehavior     string          `json:"proration_behavior,omitempty" validate:"omitempty,oneof=always_invoice create_prorations none"`
+}
+
+// PortalProduct lists the prices of a product customers may switch between
+type PortalProduct struct {
+	ProductID string   `json:"product_id" validate:"required"`
+	PriceIDs  []string `json:"price_ids" validate:"required,min=1"`
+}
+
+// CreatePortalConfigurationRequest represents the request to create a portal configuration
+type CreatePortalConfigurationRequest struct {
+	Headline          string            `json:"headline,omitempty"`
+	PrivacyPolicyURL  string            `json:"privacy_policy_url,omitempty" validate:"omitempty,url"`
+	TermsOfServiceURL string            `json:"terms_of_service_url,omitempty" validate:"omitempty,url"`
+	DefaultReturnURL  string            `json:"default_return_url,omitempty" validate:"omitempty,url"`
+	Features          PortalFeatures    `json:"features"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+}
+
+// UpdatePortalConfigurationRequest represents the request to partially update a portal configuration.
+// When Features is present it replaces the feature settings, clearing allowed updates and
+// products that are left empty; the cancel mode and proration behaviors keep their current
+// value when omitted because Stripe cannot unset them.
+type UpdatePortalConfigurationRequest struct {
+	Active            *bool             `json:"active,omitempty"`
+	Headline          *string           `json:"headline,omitempty"`
+	PrivacyPolicyURL  *string           `json:"privacy_policy_url,omitempty" validate:"omitempty,url"`
+	TermsOfServiceURL *string           `json:"terms_of_service_url,omitempty" validate:"omitempty,url"`
+	DefaultReturnURL  *string           `json:"default_return_url,omitempty" validate:"omitempty,url"`
+	Features          *PortalFeatures   `json:"features,omitempty"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+}
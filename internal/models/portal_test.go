@@ -0,0 +1,78 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestCreatePortalConfigurationRequest_Validation(t *testing.T) {
+	validator := validator.New()
+
+	tests := []struct {
+		name    string
+		request CreatePortalConfigurationRequest
+		wantErr bool
+	}{
+		{
+			name: "cancel and plan switch enabled",
+			request: CreatePortalConfigurationRequest{
+				Headline:         "Manage your plan",
+				DefaultReturnURL: "https://example.com/account",
+				Features: PortalFeatures{
+					InvoiceHistory:     true,
+					SubscriptionCancel: PortalSubscriptionCancel{Enabled: true, Mode: "at_period_end"},
+					SubscriptionUpdate: PortalSubscriptionUpdate{
+						Enabled:               true,
+						DefaultAllowedUpdates: []string{"price", "quantity"},
+						Products:              []PortalProduct{{ProductID: "prod_123", PriceIDs: []string{"price_1", "price_2"}}},
+						ProrationBehavior:     "create_prorations",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "all features disabled",
+			request: CreatePortalConfigurationRequest{},
+			wantErr: false,
+		},
+		{
+			name: "invalid cancel mode",
+			request: CreatePortalConfigurationRequest{
+				Features: PortalFeatures{SubscriptionCancel: PortalSubscriptionCancel{Enabled: true, Mode: "whenever"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid allowed update",
+			request: CreatePortalConfigurationRequest{
+				Features: PortalFeatures{SubscriptionUpdate: PortalSubscriptionUpdate{DefaultAllowedUpdates: []string{"interval"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "product without prices",
+			request: CreatePortalConfigurationRequest{
+				Features: PortalFeatures{SubscriptionUpdate: PortalSubscriptionUpdate{Products: []PortalProduct{{ProductID: "prod_123"}}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid privacy policy url",
+			request: CreatePortalConfigurationRequest{
+				PrivacyPolicyURL: "not a url",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Struct(tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validation error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,16 +1,22 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 // Product represents a product
 type Product struct {
-	ID          string            `json:"id"`
-	Name        string            `json:"name"`
-	Description string            `json:"description,omitempty"`
-	Active      bool              `json:"active"`
-	Metadata    map[string]string `json:"metadata,omitempty"`
-	CreatedAt   time.Time         `json:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
+	ID             string            `json:"id"`
+	Name           string            `json:"name"`
+	Description    string            `json:"description,omitempty"`
+	Images         []string          `json:"images,omitempty"`
+	DefaultPriceID string            `json:"default_price_id,omitempty"`
+	Active         bool              `json:"active"`
+	Metadata       map[string]string `json:"metadata,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
 }
 
 // CreateProductRequest represents the request to create a product
@@ -21,47 +27,362 @@ type CreateProductRequest struct {
 	Metadata    map[string]string `json:"metadata,omitempty"`
 }
 
+// UpdateProductRequest represents the request to partially update a product.
+// Only fields present in the request are changed; an empty Images list removes all
+// images and an empty DefaultPriceID unsets the default price.
+type UpdateProductRequest struct {
+	Name           *string           `json:"name,omitempty" validate:"omitempty,min=1"`
+	Description    *string           `json:"description,omitempty"`
+	Images         *[]string         `json:"images,omitempty" validate:"omitempty,max=8,dive,url"`
+	DefaultPriceID *string           `json:"default_price_id,omitempty"`
+	Active         *bool             `json:"active,omitempty"`
+	Metadata       map[string]string `json:"metadata,omitempty"`
+}
+
+// DeletedProduct represents the response when a product is deleted
+type DeletedProduct struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// ListProductsRequest represents the request to list products
+type ListProductsRequest struct {
+	Limit  int64  `json:"limit,omitempty"`
+	Cursor string `json:"cursor,omitempty"`
+	Active *bool  `json:"active,omitempty"`
+}
+
+// ListProductsResponse represents the response when listing products
+type ListProductsResponse struct {
+	Products   []Product `json:"products"`
+	HasMore    bool      `json:"has_more"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
+
 // Price represents a price for a product
 type Price struct {
-	ID                string            `json:"id"`
-	ProductID         string            `json:"product_id"`
-	UnitAmount        int64             `json:"unit_amount"`
-	Currency          string            `json:"currency"`
-	Type              string            `json:"type"`
-	RecurringInterval string            `json:"recurring_interval,omitempty"`
-	Active            bool              `json:"active"`
-	Metadata          map[string]string `json:"metadata,omitempty"`
-	CreatedAt         time.Time         `json:"created_at"`
-	UpdatedAt         time.Time         `json:"updated_at"`
+	ID                string                    `json:"id"`
+	ProductID         string                    `json:"product_id"`
+	UnitAmount        int64                     `json:"unit_amount"`
+	Currency          string                    `json:"currency"`
+	CurrencyOptions   map[string]CurrencyOption `json:"currency_options,omitempty"`
+	Type              string                    `json:"type"`
+	RecurringInterval string                    `json:"recurring_interval,omitempty"`
+	IntervalCount     int64                     `json:"interval_count,omitempty"`
+	UsageType         string                    `json:"usage_type,omitempty"`
+	AggregateUsage    string                    `json:"aggregate_usage,omitempty"`
+	TrialPeriodDays   int64                     `json:"trial_period_days,omitempty"`
+	BillingScheme     string                    `json:"billing_scheme,omitempty"`
+	TiersMode         string                    `json:"tiers_mode,omitempty"`
+	Tiers             []PriceTier               `json:"tiers,omitempty"`
+	TransformQuantity *TransformQuantity        `json:"transform_quantity,omitempty"`
+	Nickname          string                    `json:"nickname,omitempty"`
+	LookupKey         string                    `json:"lookup_key,omitempty"`
+	Active            bool                      `json:"active"`
+	Metadata          map[string]string         `json:"metadata,omitempty"`
+	CreatedAt         time.Time                 `json:"created_at"`
+	UpdatedAt         time.Time                 `json:"updated_at"`
+}
+
+// CurrencyOption is the amount a price charges in one of its additional currencies
+type CurrencyOption struct {
+	UnitAmount int64 `json:"unit_amount" validate:"required,min=1"`
 }
 
-// CreatePriceRequest represents the request to create a price
+// PriceTier is one tier of a tiered price. UnitAmount is charged per unit and
+// FlatAmount once for the tier; at least one of them must be set.
+type PriceTier struct {
+	UpTo       TierUpTo `json:"up_to" validate:"required"`
+	UnitAmount *int64   `json:"unit_amount,omitempty" validate:"omitempty,min=0"`
+	FlatAmount *int64   `json:"flat_amount,omitempty" validate:"omitempty,min=0"`
+}
+
+// TierUpTo is the upper bound in units of a price tier. In JSON it is either a
+// quantity or the string "inf", which marks the final, unbounded tier.
+type TierUpTo int64
+
+// TierUpToInf is the upper bound of the final tier
+const TierUpToInf TierUpTo = -1
+
+// MarshalJSON encodes the final tier's bound as "inf"
+func (u TierUpTo) MarshalJSON() ([]byte, error) {
+	if u == TierUpToInf {
+		return json.Marshal("inf")
+	}
+	return json.Marshal(int64(u))
+}
+
+// UnmarshalJSON accepts a positive quantity or the string "inf"; only "inf"
+// produces TierUpToInf
+func (u *TierUpTo) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		if text != "inf" {
+			return fmt.Errorf("up_to must be a quantity or \"inf\", got %q", text)
+		}
+		*u = TierUpToInf
+		return nil
+	}
+
+	var quantity int64
+	if err := json.Unmarshal(data, &quantity); err != nil {
+		return fmt.Errorf("up_to must be a quantity or \"inf\": %w", err)
+	}
+	if quantity < 1 {
+		return fmt.Errorf("up_to must be a positive quantity or \"inf\", got %d", quantity)
+	}
+	*u = TierUpTo(quantity)
+	return nil
+}
+
+// TransformQuantity divides the reported quantity before the price is applied,
+// e.g. to bill per 1000 API calls
+type TransformQuantity struct {
+	DivideBy int64  `json:"divide_by" validate:"required,min=1"`
+	Round    string `json:"round" validate:"required,oneof=up down"`
+}
+
+// CreatePriceRequest represents the request to create a price.
+// Recurring prices require RecurringInterval; IntervalCount, UsageType, AggregateUsage
+// and TrialPeriodDays only apply to them. Tiered prices set their amounts on Tiers
+// instead of UnitAmount. CurrencyOptions adds per-unit amounts in other currencies,
+// keyed by currency code. TransferLookupKey moves LookupKey from the price that
+// currently holds it.
 type CreatePriceRequest struct {
-	ProductID         string            `json:"product_id" validate:"required"`
-	UnitAmount        int64             `json:"unit_amount" validate:"required,min=1"`
-	Currency          string            `json:"currency" validate:"required,len=3"`
-	Type              string            `json:"type" validate:"required,oneof=one_time recurring"`
-	RecurringInterval string            `json:"recurring_interval,omitempty"`
-	Active            bool              `json:"active"`
+	ProductID         string                    `json:"product_id" validate:"required"`
+	UnitAmount        int64                     `json:"unit_amount" validate:"required_unless=BillingScheme tiered,omitempty,min=1"`
+	Currency          string                    `json:"currency" validate:"required,len=3"`
+	CurrencyOptions   map[string]CurrencyOption `json:"currency_options,omitempty" validate:"omitempty,dive"`
+	Type              string                    `json:"type" validate:"required,oneof=one_time recurring"`
+	RecurringInterval string                    `json:"recurring_interval,omitempty" validate:"omitempty,oneof=day week month year"`
+	IntervalCount     int64                     `json:"interval_count,omitempty" validate:"omitempty,min=1"`
+	UsageType         string                    `json:"usage_type,omitempty" validate:"omitempty,oneof=licensed metered"`
+	AggregateUsage    string                    `json:"aggregate_usage,omitempty" validate:"omitempty,oneof=sum last_during_period last_ever max"`
+	TrialPeriodDays   int64                     `json:"trial_period_days,omitempty" validate:"omitempty,min=1,max=730"`
+	BillingScheme     string                    `json:"billing_scheme,omitempty" validate:"omitempty,oneof=per_unit tiered"`
+	TiersMode         string                    `json:"tiers_mode,omitempty" validate:"omitempty,oneof=graduated volume"`
+	Tiers             []PriceTier               `json:"tiers,omitempty" validate:"omitempty,dive"`
+	TransformQuantity *TransformQuantity        `json:"transform_quantity,omitempty"`
+	Nickname          string                    `json:"nickname,omitempty"`
+	LookupKey         string                    `json:"lookup_key,omitempty" validate:"max=200"`
+	TransferLookupKey bool                      `json:"transfer_lookup_key,omitempty" validate:"excluded_without=LookupKey"`
+	Active            bool                      `json:"active"`
+	Metadata          map[string]string         `json:"metadata,omitempty"`
+}
+
+// UpdatePriceRequest represents the request to partially update a price.
+// Amounts, currency and interval are immutable on Stripe prices; create a new price instead.
+// An empty LookupKey removes the key, and TransferLookupKey moves it from the price
+// that currently holds it.
+type UpdatePriceRequest struct {
+	Active            *bool             `json:"active,omitempty"`
+	Nickname          *string           `json:"nickname,omitempty"`
+	LookupKey         *string           `json:"lookup_key,omitempty" validate:"omitempty,max=200"`
+	TransferLookupKey bool              `json:"transfer_lookup_key,omitempty" validate:"excluded_without=LookupKey"`
 	Metadata          map[string]string `json:"metadata,omitempty"`
 }
 
+// ListPricesRequest represents the request to list prices.
+// LookupKeys resolves prices by their stable lookup keys instead of IDs.
+type ListPricesRequest struct {
+	Limit      int64    `json:"limit,omitempty"`
+	Cursor     string   `json:"cursor,omitempty"`
+	ProductID  string   `json:"product_id,omitempty"`
+	Active     *bool    `json:"active,omitempty"`
+	Type       string   `json:"type,omitempty" validate:"omitempty,oneof=one_time recurring"`
+	Currency   string   `json:"currency,omitempty" validate:"omitempty,len=3"`
+	LookupKeys []string `json:"lookup_keys,omitempty" validate:"max=10"`
+}
+
+// ListPricesResponse represents the response when listing prices
+type ListPricesResponse struct {
+	Prices     []Price `json:"prices"`
+	HasMore    bool    `json:"has_more"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
 // Subscription represents a subscription
 type Subscription struct {
-	ID                 string            `json:"id"`
-	CustomerID         string            `json:"customer_id"`
-	PriceID            string            `json:"price_id"`
-	Status             string            `json:"status"`
-	CurrentPeriodStart time.Time         `json:"current_period_start"`
-	CurrentPeriodEnd   time.Time         `json:"current_period_end"`
-	Metadata           map[string]string `json:"metadata,omitempty"`
-	CreatedAt          time.Time         `json:"created_at"`
-	UpdatedAt          time.Time         `json:"updated_at"`
-}
-
-// CreateSubscriptionRequest represents the request to create a subscription
+	ID                     string                     `json:"id"`
+	CustomerID             string                     `json:"customer_id"`
+	PriceID                string                     `json:"price_id"`
+	Items                  []SubscriptionItem         `json:"items"`
+	Status                 string                     `json:"status"`
+	CurrentPeriodStart     time.Time                  `json:"current_period_start"`
+	CurrentPeriodEnd       time.Time                  `json:"current_period_end"`
+	CancelAtPeriodEnd      bool                       `json:"cancel_at_period_end"`
+	CancelAt               *time.Time                 `json:"cancel_at,omitempty"`
+	CanceledAt             *time.Time                 `json:"canceled_at,omitempty"`
+	PauseCollection        *SubscriptionPause         `json:"pause_collection,omitempty"`
+	TrialStart             *time.Time                 `json:"trial_start,omitempty"`
+	TrialEnd               *time.Time                 `json:"trial_end,omitempty"`
+	TrialSettings          *SubscriptionTrialSettings `json:"trial_settings,omitempty"`
+	DefaultPaymentMethodID string                     `json:"default_payment_method_id,omitempty"`
+	CollectionMethod       string                     `json:"collection_method,omitempty"`
+	DaysUntilDue           int64                      `json:"days_until_due,omitempty"`
+	Metadata               map[string]string          `json:"metadata,omitempty"`
+	CreatedAt              time.Time                  `json:"created_at"`
+	UpdatedAt              time.Time                  `json:"updated_at"`
+}
+
+// SubscriptionItem represents a single price on a subscription and its quantity
+type SubscriptionItem struct {
+	ID        string            `json:"id"`
+	PriceID   string            `json:"price_id"`
+	Quantity  int64             `json:"quantity"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// SubscriptionTrialSettings controls what happens when a trial ends
+type SubscriptionTrialSettings struct {
+	EndBehavior SubscriptionTrialEndBehavior `json:"end_behavior"`
+}
+
+// SubscriptionTrialEndBehavior describes how a subscription changes at the end of its trial
+// when the customer has not provided a payment method: it is canceled, paused, or an
+// invoice is created for the customer to pay.
+type SubscriptionTrialEndBehavior struct {
+	MissingPaymentMethod string `json:"missing_payment_method" validate:"required,oneof=cancel create_invoice pause"`
+}
+
+// DeletedSubscriptionItem represents the response when removing a subscription item
+type DeletedSubscriptionItem struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// SubscriptionPause describes a paused subscription's invoice handling. The subscription
+// stays active, but invoices are drafted, marked uncollectible or voided per Behavior
+// until ResumesAt or until collection is resumed.
+type SubscriptionPause struct {
+	Behavior  string     `json:"behavior"`
+	ResumesAt *time.Time `json:"resumes_at,omitempty"`
+}
+
+// CreateSubscriptionRequest represents the request to create a subscription, either for
+// a single PriceID and Quantity or for up to 20 Items (e.g. a base plan plus per-seat add-ons).
+// A trial is started with either TrialPeriodDays or TrialEnd (a Unix timestamp).
+// PaymentBehavior controls how a first payment that needs customer action is handled;
+// default_incomplete leaves the subscription incomplete until the payment is confirmed.
 type CreateSubscriptionRequest struct {
-	CustomerID string            `json:"customer_id" validate:"required"`
-	PriceID    string            `json:"price_id" validate:"required"`
-	Metadata   map[string]string `json:"metadata,omitempty"`
+	CustomerID      string                     `json:"customer_id" validate:"required"`
+	PriceID         string                     `json:"price_id,omitempty" validate:"required_without=Items,excluded_with=Items"`
+	Quantity        int64                      `json:"quantity,omitempty" validate:"omitempty,min=1,excluded_with=Items"`
+	Items           []SubscriptionItemRequest  `json:"items,omitempty" validate:"omitempty,max=20,dive"`
+	TrialPeriodDays int64                      `json:"trial_period_days,omitempty" validate:"omitempty,min=1,max=730,excluded_with=TrialEnd"`
+	TrialEnd        int64                      `json:"trial_end,omitempty"`
+	TrialSettings   *SubscriptionTrialSettings `json:"trial_settings,omitempty"`
+	PaymentBehavior string                     `json:"payment_behavior,omitempty" validate:"omitempty,oneof=allow_incomplete default_incomplete error_if_incomplete pending_if_incomplete"`
+	Metadata        map[string]string          `json:"metadata,omitempty"`
+}
+
+// SubscriptionItemRequest describes one price on a new multi-item subscription
+type SubscriptionItemRequest struct {
+	PriceID  string            `json:"price_id" validate:"required"`
+	Quantity int64             `json:"quantity,omitempty" validate:"omitempty,min=1"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// AddSubscriptionItemRequest represents the request to add a price to an existing subscription
+type AddSubscriptionItemRequest struct {
+	PriceID           string            `json:"price_id" validate:"required"`
+	Quantity          int64             `json:"quantity,omitempty" validate:"omitempty,min=1"`
+	ProrationBehavior string            `json:"proration_behavior,omitempty" validate:"omitempty,oneof=always_invoice create_prorations none"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+}
+
+// UpdateSubscriptionItemRequest represents the request to partially update a subscription
+// item, e.g. changing the seat count.
+type UpdateSubscriptionItemRequest struct {
+	PriceID           *string           `json:"price_id,omitempty" validate:"omitempty,min=1"`
+	Quantity          *int64            `json:"quantity,omitempty" validate:"omitempty,min=1"`
+	ProrationBehavior string            `json:"proration_behavior,omitempty" validate:"omitempty,oneof=always_invoice create_prorations none"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+}
+
+// RemoveSubscriptionItemRequest represents the options for removing a subscription item.
+// ClearUsage discards usage reported for a metered price in the current period.
+type RemoveSubscriptionItemRequest struct {
+	ProrationBehavior string `json:"proration_behavior,omitempty" validate:"omitempty,oneof=always_invoice create_prorations none"`
+	ClearUsage        bool   `json:"clear_usage,omitempty"`
+}
+
+// CancelSubscriptionRequest represents the options for canceling a subscription.
+// Mode defaults to immediately; at_period_end keeps access until the current period
+// ends and at_timestamp cancels at CancelAt (a Unix timestamp). Prorate credits unused
+// time and InvoiceNow bills pending usage and prorations right away; InvoiceNow only
+// applies to immediate cancellation.
+type CancelSubscriptionRequest struct {
+	Mode       string `json:"mode,omitempty" validate:"omitempty,oneof=immediately at_period_end at_timestamp"`
+	CancelAt   int64  `json:"cancel_at,omitempty" validate:"required_if=Mode at_timestamp"`
+	Prorate    *bool  `json:"prorate,omitempty"`
+	InvoiceNow *bool  `json:"invoice_now,omitempty"`
+}
+
+// UpdateSubscriptionRequest represents the request to partially update a subscription.
+// Invoices are emailed to the customer when CollectionMethod is send_invoice, which
+// requires DaysUntilDue.
+type UpdateSubscriptionRequest struct {
+	DefaultPaymentMethodID *string           `json:"default_payment_method_id,omitempty"`
+	CollectionMethod       *string           `json:"collection_method,omitempty" validate:"omitempty,oneof=charge_automatically send_invoice"`
+	DaysUntilDue           *int64            `json:"days_until_due,omitempty" validate:"omitempty,min=1"`
+	Metadata               map[string]string `json:"metadata,omitempty"`
+}
+
+// ChangePlanRequest represents the request to switch a subscription to a different price.
+// ProrationDate pins the proration calculation to the timestamp returned by a preview
+// so the amount charged matches what was shown to the customer.
+type ChangePlanRequest struct {
+	PriceID           string `json:"price_id" validate:"required"`
+	ProrationBehavior string `json:"proration_behavior,omitempty" validate:"omitempty,oneof=always_invoice create_prorations none"`
+	ProrationDate     int64  `json:"proration_date,omitempty"`
+}
+
+// PlanChangePreview represents the upcoming invoice for a plan change. ProrationAmount
+// is the net of the proration lines (charged immediately with always_invoice, otherwise
+// added to the next invoice) and AmountDue is the total of the upcoming invoice.
+type PlanChangePreview struct {
+	SubscriptionID  string          `json:"subscription_id"`
+	PriceID         string          `json:"price_id"`
+	Currency        string          `json:"currency"`
+	ProrationDate   int64           `json:"proration_date"`
+	ProrationAmount int64           `json:"proration_amount"`
+	AmountDue       int64           `json:"amount_due"`
+	Lines           []ProrationLine `json:"lines"`
+}
+
+// ProrationLine represents a single proration line item on an upcoming invoice
+type ProrationLine struct {
+	Description string    `json:"description"`
+	Amount      int64     `json:"amount"`
+	Currency    string    `json:"currency"`
+	PriceID     string    `json:"price_id,omitempty"`
+	Quantity    int64     `json:"quantity"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+}
+
+// PauseSubscriptionRequest represents the request to pause payment collection.
+// Behavior defaults to void; ResumesAt is an optional Unix timestamp.
+type PauseSubscriptionRequest struct {
+	Behavior  string `json:"behavior,omitempty" validate:"omitempty,oneof=keep_as_draft mark_uncollectible void"`
+	ResumesAt int64  `json:"resumes_at,omitempty"`
+}
+
+// ListSubscriptionsRequest represents the request to list subscriptions.
+// Stripe omits canceled subscriptions unless Status is canceled, ended or all.
+type ListSubscriptionsRequest struct {
+	Limit      int64  `json:"limit,omitempty"`
+	Cursor     string `json:"cursor,omitempty"`
+	CustomerID string `json:"customer_id,omitempty"`
+	PriceID    string `json:"price_id,omitempty"`
+	Status     string `json:"status,omitempty" validate:"omitempty,oneof=active all canceled ended incomplete incomplete_expired past_due paused trialing unpaid"`
+}
+
+// ListSubscriptionsResponse represents the response when listing subscriptions
+type ListSubscriptionsResponse struct {
+	Subscriptions []Subscription `json:"subscriptions"`
+	HasMore       bool           `json:"has_more"`
+	NextCursor    string         `json:"next_cursor,omitempty"`
 }
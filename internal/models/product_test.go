@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -51,6 +52,66 @@ func TestCreateProductRequest_Validation(t *testing.T) {
 	}
 }
 
+func TestUpdateProductRequest_Validation(t *testing.T) {
+	validator := validator.New()
+
+	name := "Pro plan"
+	emptyName := ""
+	images := []string{"https://example.com/pro.png"}
+	noImages := []string{}
+	badImages := []string{"not a url"}
+	tooManyImages := make([]string, 9)
+	for i := range tooManyImages {
+		tooManyImages[i] = "https://example.com/image.png"
+	}
+
+	tests := []struct {
+		name    string
+		request UpdateProductRequest
+		wantErr bool
+	}{
+		{
+			name:    "empty update",
+			request: UpdateProductRequest{},
+			wantErr: false,
+		},
+		{
+			name:    "name and images",
+			request: UpdateProductRequest{Name: &name, Images: &images},
+			wantErr: false,
+		},
+		{
+			name:    "remove all images",
+			request: UpdateProductRequest{Images: &noImages},
+			wantErr: false,
+		},
+		{
+			name:    "empty name",
+			request: UpdateProductRequest{Name: &emptyName},
+			wantErr: true,
+		},
+		{
+			name:    "invalid image URL",
+			request: UpdateProductRequest{Images: &badImages},
+			wantErr: true,
+		},
+		{
+			name:    "too many images",
+			request: UpdateProductRequest{Images: &tooManyImages},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Struct(tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateProductRequest validation = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestCreatePriceRequest_Validation(t *testing.T) {
 	validator := validator.New()
 
@@ -122,6 +183,106 @@ func TestCreatePriceRequest_Validation(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "with recurring options",
+			request: CreatePriceRequest{
+				ProductID:         "prod_123",
+				UnitAmount:        1000,
+				Currency:          "usd",
+				Type:              "recurring",
+				RecurringInterval: "week",
+				IntervalCount:     2,
+				UsageType:         "metered",
+				AggregateUsage:    "last_during_period",
+				TrialPeriodDays:   30,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid recurring interval",
+			request: CreatePriceRequest{
+				ProductID:         "prod_123",
+				UnitAmount:        1000,
+				Currency:          "usd",
+				Type:              "recurring",
+				RecurringInterval: "fortnight",
+			},
+			wantErr: true,
+		},
+		{
+			name: "tiered without unit amount",
+			request: CreatePriceRequest{
+				ProductID:         "prod_123",
+				Currency:          "usd",
+				Type:              "recurring",
+				RecurringInterval: "month",
+				BillingScheme:     "tiered",
+				TiersMode:         "graduated",
+				Tiers: []PriceTier{
+					{UpTo: 1000, UnitAmount: int64Ptr(0)},
+					{UpTo: TierUpToInf, UnitAmount: int64Ptr(2)},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "tier without up_to",
+			request: CreatePriceRequest{
+				ProductID:         "prod_123",
+				Currency:          "usd",
+				Type:              "recurring",
+				RecurringInterval: "month",
+				BillingScheme:     "tiered",
+				TiersMode:         "volume",
+				Tiers:             []PriceTier{{UnitAmount: int64Ptr(5)}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid transform quantity rounding",
+			request: CreatePriceRequest{
+				ProductID:         "prod_123",
+				UnitAmount:        100,
+				Currency:          "usd",
+				Type:              "one_time",
+				TransformQuantity: &TransformQuantity{DivideBy: 1000, Round: "nearest"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "with currency options",
+			request: CreatePriceRequest{
+				ProductID:       "prod_123",
+				UnitAmount:      1000,
+				Currency:        "usd",
+				Type:            "one_time",
+				CurrencyOptions: map[string]CurrencyOption{"eur": {UnitAmount: 900}, "gbp": {UnitAmount: 800}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "currency option without amount",
+			request: CreatePriceRequest{
+				ProductID:       "prod_123",
+				UnitAmount:      1000,
+				Currency:        "usd",
+				Type:            "one_time",
+				CurrencyOptions: map[string]CurrencyOption{"eur": {}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "trial too long",
+			request: CreatePriceRequest{
+				ProductID:         "prod_123",
+				UnitAmount:        1000,
+				Currency:          "usd",
+				Type:              "recurring",
+				RecurringInterval: "month",
+				TrialPeriodDays:   731,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -134,6 +295,85 @@ func TestCreatePriceRequest_Validation(t *testing.T) {
 	}
 }
 
+func TestUpdatePriceRequest_Validation(t *testing.T) {
+	validator := validator.New()
+
+	lookupKey := "pro_monthly"
+	clearKey := ""
+
+	tests := []struct {
+		name    string
+		request UpdatePriceRequest
+		wantErr bool
+	}{
+		{
+			name:    "empty update",
+			request: UpdatePriceRequest{},
+			wantErr: false,
+		},
+		{
+			name:    "transfer lookup key",
+			request: UpdatePriceRequest{LookupKey: &lookupKey, TransferLookupKey: true},
+			wantErr: false,
+		},
+		{
+			name:    "remove lookup key",
+			request: UpdatePriceRequest{LookupKey: &clearKey},
+			wantErr: false,
+		},
+		{
+			name:    "transfer without lookup key",
+			request: UpdatePriceRequest{TransferLookupKey: true},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Struct(tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdatePriceRequest validation = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestTierUpTo_JSON(t *testing.T) {
+	var tiers []PriceTier
+	if err := json.Unmarshal([]byte(`[{"up_to":1000,"unit_amount":5},{"up_to":"inf","flat_amount":2000}]`), &tiers); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	if tiers[0].UpTo != 1000 || tiers[1].UpTo != TierUpToInf {
+		t.Errorf("Expected up_to 1000 and inf, got %d and %d", tiers[0].UpTo, tiers[1].UpTo)
+	}
+
+	data, err := json.Marshal(tiers)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	expected := `[{"up_to":1000,"unit_amount":5},{"up_to":"inf","flat_amount":2000}]`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	var upTo TierUpTo
+	if err := json.Unmarshal([]byte(`"infinity"`), &upTo); err == nil {
+		t.Error("Expected error for unknown up_to string")
+	}
+
+	for _, quantity := range []string{"-1", "0"} {
+		if err := json.Unmarshal([]byte(quantity), &upTo); err == nil {
+			t.Errorf("Expected error for up_to %s", quantity)
+		}
+	}
+}
+
 func TestCreateSubscriptionRequest_Validation(t *testing.T) {
 	validator := validator.New()
 
@@ -173,6 +413,111 @@ func TestCreateSubscriptionRequest_Validation(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "single price with quantity",
+			request: CreateSubscriptionRequest{
+				CustomerID: "cus_123",
+				PriceID:    "price_123",
+				Quantity:   3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "multiple items",
+			request: CreateSubscriptionRequest{
+				CustomerID: "cus_123",
+				Items: []SubscriptionItemRequest{
+					{PriceID: "price_base"},
+					{PriceID: "price_seat", Quantity: 5},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "price id with items",
+			request: CreateSubscriptionRequest{
+				CustomerID: "cus_123",
+				PriceID:    "price_123",
+				Items:      []SubscriptionItemRequest{{PriceID: "price_seat"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "quantity with items",
+			request: CreateSubscriptionRequest{
+				CustomerID: "cus_123",
+				Quantity:   2,
+				Items:      []SubscriptionItemRequest{{PriceID: "price_seat"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "item without price",
+			request: CreateSubscriptionRequest{
+				CustomerID: "cus_123",
+				Items:      []SubscriptionItemRequest{{Quantity: 2}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "trial with end behavior",
+			request: CreateSubscriptionRequest{
+				CustomerID:      "cus_123",
+				PriceID:         "price_123",
+				TrialPeriodDays: 14,
+				TrialSettings: &SubscriptionTrialSettings{
+					EndBehavior: SubscriptionTrialEndBehavior{MissingPaymentMethod: "create_invoice"},
+				},
+				PaymentBehavior: "default_incomplete",
+			},
+			wantErr: false,
+		},
+		{
+			name: "trial days and trial end",
+			request: CreateSubscriptionRequest{
+				CustomerID:      "cus_123",
+				PriceID:         "price_123",
+				TrialPeriodDays: 14,
+				TrialEnd:        1893456000,
+			},
+			wantErr: true,
+		},
+		{
+			name: "trial longer than two years",
+			request: CreateSubscriptionRequest{
+				CustomerID:      "cus_123",
+				PriceID:         "price_123",
+				TrialPeriodDays: 731,
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing end behavior",
+			request: CreateSubscriptionRequest{
+				CustomerID:      "cus_123",
+				PriceID:         "price_123",
+				TrialPeriodDays: 14,
+				TrialSettings:   &SubscriptionTrialSettings{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid payment behavior",
+			request: CreateSubscriptionRequest{
+				CustomerID:      "cus_123",
+				PriceID:         "price_123",
+				PaymentBehavior: "charge_later",
+			},
+			wantErr: true,
+		},
+		{
+			name: "too many items",
+			request: CreateSubscriptionRequest{
+				CustomerID: "cus_123",
+				Items:      make([]SubscriptionItemRequest, 21),
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -185,6 +530,206 @@ func TestCreateSubscriptionRequest_Validation(t *testing.T) {
 	}
 }
 
+func TestUpdateSubscriptionItemRequest_Validation(t *testing.T) {
+	validator := validator.New()
+
+	emptyPrice := ""
+
+	tests := []struct {
+		name    string
+		request UpdateSubscriptionItemRequest
+		wantErr bool
+	}{
+		{
+			name:    "change quantity",
+			request: UpdateSubscriptionItemRequest{Quantity: int64Ptr(10), ProrationBehavior: "always_invoice"},
+			wantErr: false,
+		},
+		{
+			name:    "zero quantity",
+			request: UpdateSubscriptionItemRequest{Quantity: int64Ptr(0)},
+			wantErr: true,
+		},
+		{
+			name:    "empty price",
+			request: UpdateSubscriptionItemRequest{PriceID: &emptyPrice},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Struct(tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateSubscriptionItemRequest validation = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCancelSubscriptionRequest_Validation(t *testing.T) {
+	validator := validator.New()
+
+	tests := []struct {
+		name    string
+		request CancelSubscriptionRequest
+		wantErr bool
+	}{
+		{
+			name:    "default mode",
+			request: CancelSubscriptionRequest{},
+			wantErr: false,
+		},
+		{
+			name:    "at period end",
+			request: CancelSubscriptionRequest{Mode: "at_period_end"},
+			wantErr: false,
+		},
+		{
+			name:    "at timestamp",
+			request: CancelSubscriptionRequest{Mode: "at_timestamp", CancelAt: 1893456000},
+			wantErr: false,
+		},
+		{
+			name:    "at timestamp without cancel_at",
+			request: CancelSubscriptionRequest{Mode: "at_timestamp"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid mode",
+			request: CancelSubscriptionRequest{Mode: "later"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Struct(tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CancelSubscriptionRequest validation = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateSubscriptionRequest_Validation(t *testing.T) {
+	validator := validator.New()
+
+	sendInvoice := "send_invoice"
+	manual := "manual"
+
+	tests := []struct {
+		name    string
+		request UpdateSubscriptionRequest
+		wantErr bool
+	}{
+		{
+			name:    "empty update",
+			request: UpdateSubscriptionRequest{},
+			wantErr: false,
+		},
+		{
+			name:    "send invoice",
+			request: UpdateSubscriptionRequest{CollectionMethod: &sendInvoice, DaysUntilDue: int64Ptr(30)},
+			wantErr: false,
+		},
+		{
+			name:    "invalid collection method",
+			request: UpdateSubscriptionRequest{CollectionMethod: &manual},
+			wantErr: true,
+		},
+		{
+			name:    "zero days until due",
+			request: UpdateSubscriptionRequest{CollectionMethod: &sendInvoice, DaysUntilDue: int64Ptr(0)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Struct(tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateSubscriptionRequest validation = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChangePlanRequest_Validation(t *testing.T) {
+	validator := validator.New()
+
+	tests := []struct {
+		name    string
+		request ChangePlanRequest
+		wantErr bool
+	}{
+		{
+			name:    "price only",
+			request: ChangePlanRequest{PriceID: "price_pro"},
+			wantErr: false,
+		},
+		{
+			name:    "with proration options",
+			request: ChangePlanRequest{PriceID: "price_pro", ProrationBehavior: "none", ProrationDate: 1700000000},
+			wantErr: false,
+		},
+		{
+			name:    "missing price",
+			request: ChangePlanRequest{ProrationBehavior: "create_prorations"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid proration behavior",
+			request: ChangePlanRequest{PriceID: "price_pro", ProrationBehavior: "sometimes"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Struct(tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ChangePlanRequest validation = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPauseSubscriptionRequest_Validation(t *testing.T) {
+	validator := validator.New()
+
+	tests := []struct {
+		name    string
+		request PauseSubscriptionRequest
+		wantErr bool
+	}{
+		{
+			name:    "default behavior",
+			request: PauseSubscriptionRequest{},
+			wantErr: false,
+		},
+		{
+			name:    "mark uncollectible until date",
+			request: PauseSubscriptionRequest{Behavior: "mark_uncollectible", ResumesAt: 1893456000},
+			wantErr: false,
+		},
+		{
+			name:    "invalid behavior",
+			request: PauseSubscriptionRequest{Behavior: "skip"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Struct(tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PauseSubscriptionRequest validation = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestProduct_Structure(t *testing.T) {
 	now := time.Now()
 	product := Product{
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Refund represents a refund of a captured payment
+type Refund struct {
+	ID              string            `json:"id"`
+	Amount          int64             `json:"amount"`
+	Currency        string            `json:"currency"`
+	Status          string            `json:"status"`
+	Reason          string            `json:"reason,omitempty"`
+	PaymentIntentID string            `json:"payment_intent_id,omitempty"`
+	ChargeID        string            `json:"charge_id,omitempty"`
+	FailureReason   string            `json:"failure_reason,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+// CreateRefundRequest represents the request to refund a payment intent.
+// When Amount is omitted the remaining captured amount is refunded in full.
+type CreateRefundRequest struct {
+	PaymentIntentID string            `json:"payment_intent_id" validate:"required"`
+	Amount          int64             `json:"amount,omitempty" validate:"omitempty,min=1"`
+	Reason          string            `json:"reason,omitempty" validate:"omitempty,oneof=duplicate fraudulent requested_by_customer"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+}
+
+// ListRefundsRequest represents the request to list refunds
+type ListRefundsRequest struct {
+	Limit           int64  `json:"limit,omitempty"`
+	Cursor          string `json:"cursor,omitempty"`
+	PaymentIntentID string `json:"payment_intent_id,omitempty"`
+}
+
+// ListRefundsResponse represents the response when listing refunds
+type ListRefundsResponse struct {
+	Refunds    []Refund `json:"refunds"`
+	HasMore    bool     `json:"has_more"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestCreateRefundRequest_Validation(t *testing.T) {
+	validator := validator.New()
+
+	tests := []struct {
+		name    string
+		request CreateRefundRequest
+		wantErr bool
+	}{
+		{
+			name:    "full refund",
+			request: CreateRefundRequest{PaymentIntentID: "pi_123"},
+			wantErr: false,
+		},
+		{
+			name: "partial refund with reason",
+			request: CreateRefundRequest{
+				PaymentIntentID: "pi_123",
+				Amount:          500,
+				Reason:          "duplicate",
+				Metadata:        map[string]string{"ticket": "T-42"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "missing payment intent",
+			request: CreateRefundRequest{Amount: 500},
+			wantErr: true,
+		},
+		{
+			name:    "negative amount",
+			request: CreateRefundRequest{PaymentIntentID: "pi_123", Amount: -1},
+			wantErr: true,
+		},
+		{
+			name:    "unknown reason",
+			request: CreateRefundRequest{PaymentIntentID: "pi_123", Reason: "expired_uncaptured_charge"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Struct(tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateRefundRequest validation = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
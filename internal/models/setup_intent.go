@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// SetupIntent represents a Stripe setup intent used to save a payment method without charging it
+type SetupIntent struct {
+	ID                 string            `json:"id"`
+	Status             string            `json:"status"`
+	Usage              string            `json:"usage"`
+	CustomerID         string            `json:"customer_id,omitempty"`
+	PaymentMethodID    string            `json:"payment_method_id,omitempty"`
+	PaymentMethodTypes []string          `json:"payment_method_types,omitempty"`
+	Description        string            `json:"description,omitempty"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+	ClientSecret       string            `json:"client_secret,omitempty"`
+	CancellationReason string            `json:"cancellation_reason,omitempty"`
+	CreatedAt          time.Time         `json:"created_at"`
+}
+
+// CreateSetupIntentRequest represents the request to create a setup intent.
+// Usage defaults to off_session, which allows the saved card to be charged later without the customer present.
+type CreateSetupIntentRequest struct {
+	CustomerID         string            `json:"customer_id,omitempty"`
+	PaymentMethodID    string            `json:"payment_method_id,omitempty"`
+	PaymentMethodTypes []string          `json:"payment_method_types,omitempty"`
+	Usage              string            `json:"usage,omitempty" validate:"omitempty,oneof=on_session off_session"`
+	Description        string            `json:"description,omitempty"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+}
+
+// ConfirmSetupIntentRequest represents the request to confirm a setup intent
+type ConfirmSetupIntentRequest struct {
+	PaymentMethodID string `json:"payment_method_id,omitempty"`
+	ReturnURL       string `json:"return_url,omitempty"`
+}
+
+// CancelSetupIntentRequest represents the request to cancel a setup intent
+type CancelSetupIntentRequest struct {
+	CancellationReason string `json:"cancellation_reason,omitempty" validate:"omitempty,oneof=abandoned requested_by_customer duplicate"`
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"stripe-service/internal/idempotency"
+	"stripe-service/internal/service"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header clients use to make POST requests safely retryable
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks responses served from the idempotency cache
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// idempotencyMiddleware replays the stored response for POST requests that repeat an
+// Idempotency-Key with the same body, rejects reuse of a key with a different body,
+// and forwards a key derived from it to Stripe through the request context
+func (s *Server) idempotencyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			writeMiddlewareError(w, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeMiddlewareError(w, http.StatusBadRequest, "Failed to read request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Keys are scoped to the endpoint so the same key on different routes does not collide
+		storeKey := r.Method + " " + r.URL.Path + " " + key
+		hash := sha256.Sum256(body)
+		bodyHash := hex.EncodeToString(hash[:])
+
+		stored, err := s.idempotencyStore.Begin(storeKey, bodyHash)
+		switch {
+		case errors.Is(err, idempotency.ErrKeyReused):
+			writeMiddlewareError(w, http.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request body")
+			return
+		case errors.Is(err, idempotency.ErrRequestInProgress):
+			writeMiddlewareError(w, http.StatusConflict, "A request with this Idempotency-Key is still in progress")
+			return
+		case stored != nil:
+			for name, values := range stored.Header {
+				w.Header()[name] = values
+			}
+			w.Header().Set(IdempotentReplayedHeader, "true")
+			w.WriteHeader(stored.StatusCode)
+			if _, err := w.Write(stored.Body); err != nil {
+				log.Printf("Error writing replayed response: %v", err)
+			}
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(service.WithIdempotencyKey(r.Context(), stripeIdempotencyKey(storeKey, bodyHash))))
+
+		if isRetryableStatus(recorder.statusCode) {
+			s.idempotencyStore.Release(storeKey)
+			return
+		}
+		s.idempotencyStore.Complete(storeKey, &idempotency.Response{
+			StatusCode: recorder.statusCode,
+			Header:     w.Header().Clone(),
+			Body:       recorder.body.Bytes(),
+		})
+	})
+}
+
+// isRetryableStatus reports whether a response should be released instead of cached.
+// Server errors and client errors other than conflicts are not cached so the client can
+// fix the request and retry with the same key.
+func isRetryableStatus(statusCode int) bool {
+	if statusCode >= http.StatusInternalServerError {
+		return true
+	}
+	return statusCode >= http.StatusBadRequest &&
+		statusCode != http.StatusConflict &&
+		statusCode != http.StatusUnprocessableEntity
+}
+
+// stripeIdempotencyKey derives the key sent to Stripe from the endpoint-scoped store key
+// and the body hash. A key reused on different routes does not collide at Stripe, and a
+// request corrected after a released client error reaches Stripe under a new key instead
+// of being rejected as a reuse with different parameters.
+func stripeIdempotencyKey(storeKey, bodyHash string) string {
+	hash := sha256.Sum256([]byte(storeKey + " " + bodyHash))
+	return hex.EncodeToString(hash[:])
+}
+
+// responseRecorder passes a response through while keeping a copy for the idempotency cache
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (rr *responseRecorder) WriteHeader(code int) {
+	rr.statusCode = code
+	rr.ResponseWriter.WriteHeader(code)
+}
+
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	rr.body.Write(b)
+	return rr.ResponseWriter.Write(b)
+}
+
+// writeMiddlewareError writes a JSON error in the same shape as the handlers
+func writeMiddlewareError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(map[string]string{"error": message}); err != nil {
+		log.Printf("Error encoding error response: %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"stripe-service/internal/idempotency"
+	"stripe-service/internal/service"
+)
+
+// countingHandler echoes a new resource ID per call and records the forwarded idempotency key
+type countingHandler struct {
+	calls      int
+	forwarded  string
+	statusCode int
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.calls++
+	h.forwarded = service.IdempotencyKeyFromContext(r.Context())
+	body, _ := io.ReadAll(r.Body)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(h.statusCode)
+	fmt.Fprintf(w, `{"id":"cus_%d","request":%s}`, h.calls, body)
+}
+
+func bodyHash(body string) string {
+	hash := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(hash[:])
+}
+
+func newIdempotencyTestServer() *Server {
+	return &Server{idempotencyStore: idempotency.NewStore(idempotency.DefaultTTL)}
+}
+
+func postWithKey(handler http.Handler, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", path, bytes.NewReader([]byte(body)))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestIdempotencyMiddleware_ReplaysSameRequest(t *testing.T) {
+	next := &countingHandler{statusCode: http.StatusCreated}
+	handler := newIdempotencyTestServer().idempotencyMiddleware(next)
+
+	first := postWithKey(handler, "/api/v1/customers", "key-123", `{"email":"a@example.com"}`)
+	second := postWithKey(handler, "/api/v1/customers", "key-123", `{"email":"a@example.com"}`)
+
+	if next.calls != 1 {
+		t.Errorf("Expected handler to run once, ran %d times", next.calls)
+	}
+	if next.forwarded != stripeIdempotencyKey("POST /api/v1/customers key-123", bodyHash(`{"email":"a@example.com"}`)) {
+		t.Errorf("Expected endpoint-scoped idempotency key to be forwarded in context, got %q", next.forwarded)
+	}
+	if second.Code != http.StatusCreated {
+		t.Errorf("Expected replayed status %d, got %d", http.StatusCreated, second.Code)
+	}
+	if second.Body.String() != first.Body.String() {
+		t.Errorf("Expected replayed body %q, got %q", first.Body.String(), second.Body.String())
+	}
+	if second.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Error("Expected replayed response to carry Idempotent-Replayed header")
+	}
+	if first.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Error("Expected original response not to carry Idempotent-Replayed header")
+	}
+}
+
+func TestIdempotencyMiddleware_DifferentBody(t *testing.T) {
+	next := &countingHandler{statusCode: http.StatusCreated}
+	handler := newIdempotencyTestServer().idempotencyMiddleware(next)
+
+	postWithKey(handler, "/api/v1/customers", "key-123", `{"email":"a@example.com"}`)
+	rr := postWithKey(handler, "/api/v1/customers", "key-123", `{"email":"b@example.com"}`)
+
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d", http.StatusUnprocessableEntity, rr.Code)
+	}
+	if next.calls != 1 {
+		t.Errorf("Expected handler to run once, ran %d times", next.calls)
+	}
+}
+
+func TestIdempotencyMiddleware_ScopedByPath(t *testing.T) {
+	next := &countingHandler{statusCode: http.StatusCreated}
+	handler := newIdempotencyTestServer().idempotencyMiddleware(next)
+
+	postWithKey(handler, "/api/v1/customers", "key-123", `{}`)
+	customerKey := next.forwarded
+	postWithKey(handler, "/api/v1/products", "key-123", `{}`)
+
+	if next.calls != 2 {
+		t.Errorf("Expected handler to run for each path, ran %d times", next.calls)
+	}
+	if customerKey == next.forwarded {
+		t.Errorf("Expected different Stripe idempotency keys per path, got %q for both", customerKey)
+	}
+}
+
+func TestIdempotencyMiddleware_ServerErrorsNotCached(t *testing.T) {
+	next := &countingHandler{statusCode: http.StatusInternalServerError}
+	handler := newIdempotencyTestServer().idempotencyMiddleware(next)
+
+	postWithKey(handler, "/api/v1/customers", "key-123", `{}`)
+	postWithKey(handler, "/api/v1/customers", "key-123", `{}`)
+
+	if next.calls != 2 {
+		t.Errorf("Expected failed request to be retried, handler ran %d times", next.calls)
+	}
+}
+
+func TestIdempotencyMiddleware_ClientErrorsNotCached(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		expectedCalls int
+	}{
+		{name: "validation error", statusCode: http.StatusBadRequest, expectedCalls: 2},
+		{name: "not found", statusCode: http.StatusNotFound, expectedCalls: 2},
+		{name: "conflict", statusCode: http.StatusConflict, expectedCalls: 1},
+		{name: "unprocessable entity", statusCode: http.StatusUnprocessableEntity, expectedCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &countingHandler{statusCode: tt.statusCode}
+			handler := newIdempotencyTestServer().idempotencyMiddleware(next)
+
+			postWithKey(handler, "/api/v1/customers", "key-123", `{}`)
+			postWithKey(handler, "/api/v1/customers", "key-123", `{}`)
+
+			if next.calls != tt.expectedCalls {
+				t.Errorf("Expected handler to run %d times, ran %d times", tt.expectedCalls, next.calls)
+			}
+		})
+	}
+}
+
+func TestIdempotencyMiddleware_CorrectedBodyGetsNewStripeKey(t *testing.T) {
+	next := &countingHandler{statusCode: http.StatusBadRequest}
+	handler := newIdempotencyTestServer().idempotencyMiddleware(next)
+
+	postWithKey(handler, "/api/v1/customers", "key-123", `{"email":"not-an-email"}`)
+	rejected := next.forwarded
+
+	next.statusCode = http.StatusCreated
+	postWithKey(handler, "/api/v1/customers", "key-123", `{"email":"a@example.com"}`)
+
+	if next.calls != 2 {
+		t.Errorf("Expected corrected request to run, ran %d times", next.calls)
+	}
+	if next.forwarded == rejected {
+		t.Error("Expected the corrected body to be forwarded to Stripe under a different key")
+	}
+}
+
+func TestIdempotencyMiddleware_PassThrough(t *testing.T) {
+	next := &countingHandler{statusCode: http.StatusOK}
+	handler := newIdempotencyTestServer().idempotencyMiddleware(next)
+
+	// Without a key every request runs
+	postWithKey(handler, "/api/v1/customers", "", `{}`)
+	postWithKey(handler, "/api/v1/customers", "", `{}`)
+
+	// Non-POST requests ignore the key
+	req := httptest.NewRequest("GET", "/api/v1/customers", nil)
+	req.Header.Set(IdempotencyKeyHeader, "key-123")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if next.calls != 4 {
+		t.Errorf("Expected handler to run 4 times, ran %d times", next.calls)
+	}
+}
+
+func TestIdempotencyMiddleware_KeyTooLong(t *testing.T) {
+	next := &countingHandler{statusCode: http.StatusCreated}
+	handler := newIdempotencyTestServer().idempotencyMiddleware(next)
+
+	rr := postWithKey(handler, "/api/v1/customers", string(bytes.Repeat([]byte("k"), 256)), `{}`)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+	if next.calls != 0 {
+		t.Errorf("Expected handler not to run, ran %d times", next.calls)
+	}
+}
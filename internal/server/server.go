@@ -6,17 +6,21 @@ import (
 	"time"
 
 	"stripe-service/internal/handlers"
+	"stripe-service/internal/idempotency"
 
 	"github.com/gorilla/mux"
 )
 
 type Server struct {
-	router *mux.Router
+	router           *mux.Router
+	idempotencyStore *idempotency.Store
 }
 
-func NewServer(stripeHandler *handlers.StripeHandler) *Server {
-	s := &Server{}
-	s.setupRouter(stripeHandler)
+func NewServer(stripeHandler *handlers.StripeHandler, webhookHandler *handlers.WebhookHandler) *Server {
+	s := &Server{
+		idempotencyStore: idempotency.NewStore(idempotency.DefaultTTL),
+	}
+	s.setupRouter(stripeHandler, webhookHandler)
 	return s
 }
 
@@ -24,15 +28,22 @@ func (s *Server) Handler() http.Handler {
 	return s.router
 }
 
-func (s *Server) setupRouter(stripeHandler *handlers.StripeHandler) {
+func (s *Server) setupRouter(stripeHandler *handlers.StripeHandler, webhookHandler *handlers.WebhookHandler) {
 	router := mux.NewRouter()
 
 	// Add middleware
 	router.Use(s.loggingMiddleware)
 	router.Use(s.corsMiddleware)
 
+	// Webhook routes are registered before the API subrouter so they skip the idempotency
+	// middleware; Stripe events are deduplicated by the webhook event store instead
+	webhookRoutes := router.PathPrefix("/api/v1/webhooks").Subrouter()
+	webhookRoutes.HandleFunc("/stripe", webhookHandler.HandleStripeWebhook).Methods("POST")
+	webhookRoutes.HandleFunc("/stripe/replay", webhookHandler.ReplayFailedEvents).Methods("POST")
+
 	// API routes
 	api := router.PathPrefix("/api/v1").Subrouter()
+	api.Use(s.idempotencyMiddleware)
 
 	// Health check
 	api.HandleFunc("/health", stripeHandler.HealthCheck).Methods("GET", "OPTIONS")
@@ -41,24 +52,87 @@ func (s *Server) setupRouter(stripeHandler *handlers.StripeHandler) {
 	api.HandleFunc("/customers", stripeHandler.CreateCustomer).Methods("POST")
 	api.HandleFunc("/customers", stripeHandler.ListCustomers).Methods("GET")
 	api.HandleFunc("/customers/{id}", stripeHandler.GetCustomer).Methods("GET")
+	api.HandleFunc("/customers/{id}", stripeHandler.UpdateCustomer).Methods("PATCH")
+	api.HandleFunc("/customers/{id}", stripeHandler.DeleteCustomer).Methods("DELETE")
+	api.HandleFunc("/customers/{id}/payment-methods", stripeHandler.ListPaymentMethods).Methods("GET")
+	api.HandleFunc("/customers/{id}/default-payment-method", stripeHandler.SetDefaultPaymentMethod).Methods("POST")
+	api.HandleFunc("/customers/{id}/portal-session", stripeHandler.CreatePortalSession).Methods("POST")
 	// Add OPTIONS support for all customer routes
 	api.HandleFunc("/customers", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}).Methods("OPTIONS")
 
+	// Payment method routes
+	api.HandleFunc("/payment-methods/{id}", stripeHandler.GetPaymentMethod).Methods("GET")
+	api.HandleFunc("/payment-methods/{id}/attach", stripeHandler.AttachPaymentMethod).Methods("POST")
+	api.HandleFunc("/payment-methods/{id}/detach", stripeHandler.DetachPaymentMethod).Methods("POST")
+
 	// Payment intent routes
 	api.HandleFunc("/payment-intents", stripeHandler.CreatePaymentIntent).Methods("POST")
+	api.HandleFunc("/payment-intents", stripeHandler.ListPaymentIntents).Methods("GET")
+	api.HandleFunc("/payment-intents/{id}", stripeHandler.GetPaymentIntent).Methods("GET")
+	api.HandleFunc("/payment-intents/{id}", stripeHandler.UpdatePaymentIntent).Methods("PATCH")
 	api.HandleFunc("/payment-intents/{id}/confirm", stripeHandler.ConfirmPaymentIntent).Methods("POST")
+	api.HandleFunc("/payment-intents/{id}/cancel", stripeHandler.CancelPaymentIntent).Methods("POST")
+	api.HandleFunc("/payment-intents/{id}/capture", stripeHandler.CapturePaymentIntent).Methods("POST")
+
+	// Setup intent routes
+	api.HandleFunc("/setup-intents", stripeHandler.CreateSetupIntent).Methods("POST")
+	api.HandleFunc("/setup-intents/{id}", stripeHandler.GetSetupIntent).Methods("GET")
+	api.HandleFunc("/setup-intents/{id}/confirm", stripeHandler.ConfirmSetupIntent).Methods("POST")
+	api.HandleFunc("/setup-intents/{id}/cancel", stripeHandler.CancelSetupIntent).Methods("POST")
+
+	// Checkout routes
+	api.HandleFunc("/checkout/sessions", stripeHandler.CreateCheckoutSession).Methods("POST")
+	api.HandleFunc("/checkout/sessions/{id}", stripeHandler.GetCheckoutSession).Methods("GET")
+	api.HandleFunc("/checkout/sessions/{id}/line-items", stripeHandler.ListCheckoutLineItems).Methods("GET")
+
+	// Customer portal configuration routes
+	api.HandleFunc("/portal/configurations", stripeHandler.CreatePortalConfiguration).Methods("POST")
+	api.HandleFunc("/portal/configurations/{id}", stripeHandler.GetPortalConfiguration).Methods("GET")
+	api.HandleFunc("/portal/configurations/{id}", stripeHandler.UpdatePortalConfiguration).Methods("PATCH")
+
+	// Refund routes
+	api.HandleFunc("/refunds", stripeHandler.CreateRefund).Methods("POST")
+	api.HandleFunc("/refunds", stripeHandler.ListRefunds).Methods("GET")
+	api.HandleFunc("/refunds/{id}", stripeHandler.GetRefund).Methods("GET")
+	api.HandleFunc("/refunds/{id}/cancel", stripeHandler.CancelRefund).Methods("POST")
+
+	// Dispute routes
+	api.HandleFunc("/disputes", stripeHandler.ListDisputes).Methods("GET")
+	api.HandleFunc("/disputes/{id}", stripeHandler.GetDispute).Methods("GET")
+	api.HandleFunc("/disputes/{id}/evidence", stripeHandler.SubmitDisputeEvidence).Methods("POST")
+	api.HandleFunc("/disputes/{id}/close", stripeHandler.CloseDispute).Methods("POST")
 
 	// Product routes
 	api.HandleFunc("/products", stripeHandler.CreateProduct).Methods("POST")
+	api.HandleFunc("/products", stripeHandler.ListProducts).Methods("GET")
+	api.HandleFunc("/products/{id}", stripeHandler.GetProduct).Methods("GET")
+	api.HandleFunc("/products/{id}", stripeHandler.UpdateProduct).Methods("PATCH")
+	api.HandleFunc("/products/{id}", stripeHandler.DeleteProduct).Methods("DELETE")
+	api.HandleFunc("/products/{id}/archive", stripeHandler.ArchiveProduct).Methods("POST")
 
 	// Price routes
 	api.HandleFunc("/prices", stripeHandler.CreatePrice).Methods("POST")
+	api.HandleFunc("/prices", stripeHandler.ListPrices).Methods("GET")
+	api.HandleFunc("/prices/{id}", stripeHandler.GetPrice).Methods("GET")
+	api.HandleFunc("/prices/{id}", stripeHandler.UpdatePrice).Methods("PATCH")
 
 	// Subscription routes
 	api.HandleFunc("/subscriptions", stripeHandler.CreateSubscription).Methods("POST")
+	api.HandleFunc("/subscriptions", stripeHandler.ListSubscriptions).Methods("GET")
+	api.HandleFunc("/subscriptions/{id}", stripeHandler.GetSubscription).Methods("GET")
+	api.HandleFunc("/subscriptions/{id}", stripeHandler.UpdateSubscription).Methods("PATCH")
 	api.HandleFunc("/subscriptions/{id}", stripeHandler.CancelSubscription).Methods("DELETE")
+	api.HandleFunc("/subscriptions/{id}/undo-cancellation", stripeHandler.UndoSubscriptionCancellation).Methods("POST")
+	api.HandleFunc("/subscriptions/{id}/change-plan", stripeHandler.ChangeSubscriptionPlan).Methods("POST")
+	api.HandleFunc("/subscriptions/{id}/change-plan/preview", stripeHandler.PreviewSubscriptionPlanChange).Methods("GET")
+	api.HandleFunc("/subscriptions/{id}/items", stripeHandler.AddSubscriptionItem).Methods("POST")
+	api.HandleFunc("/subscriptions/{id}/items/{item_id}", stripeHandler.UpdateSubscriptionItem).Methods("PATCH")
+	api.HandleFunc("/subscriptions/{id}/items/{item_id}", stripeHandler.RemoveSubscriptionItem).Methods("DELETE")
+	api.HandleFunc("/subscriptions/{id}/end-trial", stripeHandler.EndSubscriptionTrial).Methods("POST")
+	api.HandleFunc("/subscriptions/{id}/pause", stripeHandler.PauseSubscription).Methods("POST")
+	api.HandleFunc("/subscriptions/{id}/resume", stripeHandler.ResumeSubscription).Methods("POST")
 
 	s.router = router
 }
@@ -91,8 +165,9 @@ func (s *Server) loggingMiddleware(next http.Handler) http.Handler {
 func (s *Server) corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, "+IdempotencyKeyHeader)
+		w.Header().Set("Access-Control-Expose-Headers", IdempotentReplayedHeader)
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -9,6 +9,7 @@ import (
 	"stripe-service/config"
 	"stripe-service/internal/handlers"
 	"stripe-service/internal/service"
+	"stripe-service/internal/webhooks"
 )
 
 func TestNewServer(t *testing.T) {
@@ -20,9 +21,10 @@ func TestNewServer(t *testing.T) {
 	}
 	stripeService := service.NewStripeService(cfg)
 	stripeHandler := handlers.NewStripeHandler(stripeService)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
 
 	// Test NewServer
-	server := NewServer(stripeHandler)
+	server := NewServer(stripeHandler, webhookHandler)
 
 	if server == nil {
 		t.Error("Expected server to be created, got nil")
@@ -42,9 +44,10 @@ func TestServerHandler(t *testing.T) {
 	}
 	stripeService := service.NewStripeService(cfg)
 	stripeHandler := handlers.NewStripeHandler(stripeService)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
 
 	// Create server
-	server := NewServer(stripeHandler)
+	server := NewServer(stripeHandler, webhookHandler)
 
 	// Test Handler method
 	handler := server.Handler()
@@ -67,9 +70,10 @@ func TestSetupRouter(t *testing.T) {
 	}
 	stripeService := service.NewStripeService(cfg)
 	stripeHandler := handlers.NewStripeHandler(stripeService)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
 
 	// Create server (which calls setupRouter internally)
-	server := NewServer(stripeHandler)
+	server := NewServer(stripeHandler, webhookHandler)
 
 	// Test that all expected routes are registered
 	testCases := []struct {
@@ -79,8 +83,9 @@ func TestSetupRouter(t *testing.T) {
 	}{
 		{"GET", "/api/v1/health", http.StatusOK},
 		{"OPTIONS", "/api/v1/customers", http.StatusOK},
-		{"GET", "/api/v1/customers", http.StatusInternalServerError}, // Will fail due to test key
-		{"POST", "/api/v1/customers", http.StatusBadRequest},         // Will fail due to empty body
+		{"GET", "/api/v1/customers", http.StatusInternalServerError},       // Will fail due to test key
+		{"POST", "/api/v1/customers", http.StatusBadRequest},               // Will fail due to empty body
+		{"POST", "/api/v1/webhooks/stripe", http.StatusServiceUnavailable}, // No webhook secret configured
 	}
 
 	for _, tc := range testCases {
@@ -104,9 +109,10 @@ func TestLoggingMiddleware(t *testing.T) {
 	}
 	stripeService := service.NewStripeService(cfg)
 	stripeHandler := handlers.NewStripeHandler(stripeService)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
 
 	// Create server
-	server := NewServer(stripeHandler)
+	server := NewServer(stripeHandler, webhookHandler)
 
 	// Test that logging middleware is applied
 	req := httptest.NewRequest("GET", "/api/v1/health", nil)
@@ -134,9 +140,10 @@ func TestCORSMiddleware(t *testing.T) {
 	}
 	stripeService := service.NewStripeService(cfg)
 	stripeHandler := handlers.NewStripeHandler(stripeService)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
 
 	// Create server
-	server := NewServer(stripeHandler)
+	server := NewServer(stripeHandler, webhookHandler)
 
 	t.Run("OPTIONS request", func(t *testing.T) {
 		req := httptest.NewRequest("OPTIONS", "/api/v1/customers", nil)
@@ -149,12 +156,16 @@ func TestCORSMiddleware(t *testing.T) {
 			t.Errorf("Expected Access-Control-Allow-Origin to be '*', got '%s'", rr.Header().Get("Access-Control-Allow-Origin"))
 		}
 
-		if rr.Header().Get("Access-Control-Allow-Methods") != "GET, POST, PUT, DELETE, OPTIONS" {
-			t.Errorf("Expected Access-Control-Allow-Methods to be 'GET, POST, PUT, DELETE, OPTIONS', got '%s'", rr.Header().Get("Access-Control-Allow-Methods"))
+		if rr.Header().Get("Access-Control-Allow-Methods") != "GET, POST, PUT, PATCH, DELETE, OPTIONS" {
+			t.Errorf("Expected Access-Control-Allow-Methods to be 'GET, POST, PUT, PATCH, DELETE, OPTIONS', got '%s'", rr.Header().Get("Access-Control-Allow-Methods"))
 		}
 
-		if rr.Header().Get("Access-Control-Allow-Headers") != "Content-Type, Authorization" {
-			t.Errorf("Expected Access-Control-Allow-Headers to be 'Content-Type, Authorization', got '%s'", rr.Header().Get("Access-Control-Allow-Headers"))
+		if rr.Header().Get("Access-Control-Allow-Headers") != "Content-Type, Authorization, Idempotency-Key" {
+			t.Errorf("Expected Access-Control-Allow-Headers to be 'Content-Type, Authorization, Idempotency-Key', got '%s'", rr.Header().Get("Access-Control-Allow-Headers"))
+		}
+
+		if rr.Header().Get("Access-Control-Expose-Headers") != "Idempotent-Replayed" {
+			t.Errorf("Expected Access-Control-Expose-Headers to be 'Idempotent-Replayed', got '%s'", rr.Header().Get("Access-Control-Expose-Headers"))
 		}
 
 		if rr.Code != http.StatusOK {
@@ -188,9 +199,10 @@ func TestResponseWriterWrapper(t *testing.T) {
 	}
 	stripeService := service.NewStripeService(cfg)
 	stripeHandler := handlers.NewStripeHandler(stripeService)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
 
 	// Create server
-	server := NewServer(stripeHandler)
+	server := NewServer(stripeHandler, webhookHandler)
 
 	// Test that response writer wrapper captures status codes correctly
 	req := httptest.NewRequest("GET", "/api/v1/health", nil)
@@ -243,9 +255,10 @@ func TestMiddlewareChain(t *testing.T) {
 	}
 	stripeService := service.NewStripeService(cfg)
 	stripeHandler := handlers.NewStripeHandler(stripeService)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
 
 	// Create server
-	server := NewServer(stripeHandler)
+	server := NewServer(stripeHandler, webhookHandler)
 
 	// Test that both middleware (logging and CORS) are applied in the correct order
 	req := httptest.NewRequest("GET", "/api/v1/health", nil)
@@ -264,6 +277,29 @@ func TestMiddlewareChain(t *testing.T) {
 	}
 }
 
+func TestWebhookRoutesSkipIdempotency(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	stripeHandler := handlers.NewStripeHandler(service.NewStripeService(cfg))
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
+	server := NewServer(stripeHandler, webhookHandler)
+
+	// An oversized key would be rejected by the idempotency middleware with a 400
+	req := httptest.NewRequest("POST", "/api/v1/webhooks/stripe", bytes.NewBufferString(`{}`))
+	req.Header.Set(IdempotencyKeyHeader, string(bytes.Repeat([]byte("k"), 256)))
+	rr := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(rr, req)
+
+	// Without a webhook secret the webhook handler itself answers 503
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected webhook handler to run with status %d, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+}
+
 func TestAllRoutes(t *testing.T) {
 	// Create test dependencies
 	cfg := &config.Config{
@@ -273,9 +309,10 @@ func TestAllRoutes(t *testing.T) {
 	}
 	stripeService := service.NewStripeService(cfg)
 	stripeHandler := handlers.NewStripeHandler(stripeService)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
 
 	// Create server
-	server := NewServer(stripeHandler)
+	server := NewServer(stripeHandler, webhookHandler)
 
 	// Test all registered routes
 	routes := []struct {
@@ -286,17 +323,69 @@ func TestAllRoutes(t *testing.T) {
 		{"GET", "/api/v1/customers"},
 		{"POST", "/api/v1/customers"},
 		{"GET", "/api/v1/customers/cus_123"},
+		{"PATCH", "/api/v1/customers/cus_123"},
+		{"DELETE", "/api/v1/customers/cus_123"},
+		{"GET", "/api/v1/customers/cus_123/payment-methods"},
+		{"POST", "/api/v1/customers/cus_123/default-payment-method"},
+		{"GET", "/api/v1/payment-methods/pm_123"},
+		{"POST", "/api/v1/payment-methods/pm_123/attach"},
+		{"POST", "/api/v1/payment-methods/pm_123/detach"},
 		{"POST", "/api/v1/payment-intents"},
+		{"GET", "/api/v1/payment-intents"},
+		{"GET", "/api/v1/payment-intents/pi_123"},
+		{"PATCH", "/api/v1/payment-intents/pi_123"},
 		{"POST", "/api/v1/payment-intents/pi_123/confirm"},
+		{"POST", "/api/v1/payment-intents/pi_123/cancel"},
+		{"POST", "/api/v1/payment-intents/pi_123/capture"},
+		{"POST", "/api/v1/setup-intents"},
+		{"GET", "/api/v1/setup-intents/seti_123"},
+		{"POST", "/api/v1/setup-intents/seti_123/confirm"},
+		{"POST", "/api/v1/setup-intents/seti_123/cancel"},
+		{"POST", "/api/v1/checkout/sessions"},
+		{"GET", "/api/v1/checkout/sessions/cs_test_123"},
+		{"GET", "/api/v1/checkout/sessions/cs_test_123/line-items"},
+		{"POST", "/api/v1/customers/cus_123/portal-session"},
+		{"POST", "/api/v1/portal/configurations"},
+		{"GET", "/api/v1/portal/configurations/bpc_123"},
+		{"PATCH", "/api/v1/portal/configurations/bpc_123"},
+		{"POST", "/api/v1/refunds"},
+		{"GET", "/api/v1/refunds"},
+		{"GET", "/api/v1/refunds/re_123"},
+		{"POST", "/api/v1/refunds/re_123/cancel"},
+		{"GET", "/api/v1/disputes"},
+		{"GET", "/api/v1/disputes/dp_123"},
+		{"POST", "/api/v1/disputes/dp_123/evidence"},
+		{"POST", "/api/v1/disputes/dp_123/close"},
 		{"POST", "/api/v1/products"},
+		{"GET", "/api/v1/products"},
+		{"GET", "/api/v1/products/prod_123"},
+		{"PATCH", "/api/v1/products/prod_123"},
+		{"DELETE", "/api/v1/products/prod_123"},
+		{"POST", "/api/v1/products/prod_123/archive"},
 		{"POST", "/api/v1/prices"},
+		{"GET", "/api/v1/prices"},
+		{"GET", "/api/v1/prices/price_123"},
+		{"PATCH", "/api/v1/prices/price_123"},
 		{"POST", "/api/v1/subscriptions"},
+		{"GET", "/api/v1/subscriptions"},
+		{"GET", "/api/v1/subscriptions/sub_123"},
+		{"PATCH", "/api/v1/subscriptions/sub_123"},
 		{"DELETE", "/api/v1/subscriptions/sub_123"},
+		{"POST", "/api/v1/subscriptions/sub_123/undo-cancellation"},
+		{"POST", "/api/v1/subscriptions/sub_123/change-plan"},
+		{"GET", "/api/v1/subscriptions/sub_123/change-plan/preview"},
+		{"POST", "/api/v1/subscriptions/sub_123/items"},
+		{"PATCH", "/api/v1/subscriptions/sub_123/items/si_123"},
+		{"DELETE", "/api/v1/subscriptions/sub_123/items/si_123"},
+		{"POST", "/api/v1/subscriptions/sub_123/end-trial"},
+		{"POST", "/api/v1/subscriptions/sub_123/pause"},
+		{"POST", "/api/v1/subscriptions/sub_123/resume"},
 		{"OPTIONS", "/api/v1/customers"},
 		// Test additional customer ID variations
 		{"GET", "/api/v1/customers/cus_different_id"},
 		{"DELETE", "/api/v1/subscriptions/sub_different_id"},
 		{"POST", "/api/v1/payment-intents/pi_different_id/confirm"},
+		{"POST", "/api/v1/webhooks/stripe"},
 	}
 
 	for _, route := range routes {
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"stripe-service/internal/models"
+
+	"github.com/stripe/stripe-go/v76"
+)
+
+// Checkout operations
+
+// CreateCheckoutSession creates a Stripe-hosted Checkout session
+func (s *StripeService) CreateCheckoutSession(ctx context.Context, req *models.CreateCheckoutSessionRequest) (*models.CheckoutSession, error) {
+	if err := validateCheckoutSession(req); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateCheckoutCurrency(ctx, req); err != nil {
+		return nil, err
+	}
+
+	params := &stripe.CheckoutSessionParams{
+		Mode:       stripe.String(req.Mode),
+		SuccessURL: stripe.String(req.SuccessURL),
+	}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	for _, item := range req.LineItems {
+		params.LineItems = append(params.LineItems, buildCheckoutLineItemParams(item))
+	}
+
+	if req.CancelURL != "" {
+		params.CancelURL = stripe.String(req.CancelURL)
+	}
+
+	if req.CustomerID != "" {
+		params.Customer = stripe.String(req.CustomerID)
+	}
+
+	if req.CustomerEmail != "" {
+		params.CustomerEmail = stripe.String(req.CustomerEmail)
+	}
+
+	if req.Currency != "" {
+		params.Currency = stripe.String(req.Currency)
+	}
+
+	if req.AllowPromotionCodes {
+		params.AllowPromotionCodes = stripe.Bool(true)
+	}
+
+	if req.Metadata != nil {
+		params.Metadata = req.Metadata
+	}
+
+	stripeSession, err := s.client.CheckoutSessions.New(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checkout session: %w", err)
+	}
+
+	return s.convertStripeCheckoutSession(stripeSession), nil
+}
+
+// GetCheckoutSession retrieves a Checkout session by ID
+func (s *StripeService) GetCheckoutSession(ctx context.Context, sessionID string) (*models.CheckoutSession, error) {
+	params := &stripe.CheckoutSessionParams{}
+	params.Context = ctx
+
+	stripeSession, err := s.client.CheckoutSessions.Get(sessionID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkout session: %w", err)
+	}
+
+	return s.convertStripeCheckoutSession(stripeSession), nil
+}
+
+// ListCheckoutLineItems lists a single page of a Checkout session's line items
+func (s *StripeService) ListCheckoutLineItems(ctx context.Context, req *models.ListCheckoutLineItemsRequest) (*models.ListCheckoutLineItemsResponse, error) {
+	params := &stripe.CheckoutSessionListLineItemsParams{
+		Session: stripe.String(req.SessionID),
+	}
+	params.Context = ctx
+	params.Single = true
+	params.Limit = stripe.Int64(pageLimit(req.Limit))
+
+	if req.Cursor != "" {
+		params.StartingAfter = stripe.String(req.Cursor)
+	}
+
+	iter := s.client.CheckoutSessions.ListLineItems(params)
+	lineItems := []models.CheckoutLineItemSummary{}
+
+	for iter.Next() {
+		lineItems = append(lineItems, convertStripeLineItem(iter.LineItem()))
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list checkout line items: %w", err)
+	}
+
+	response := &models.ListCheckoutLineItemsResponse{
+		LineItems: lineItems,
+		HasMore:   iter.Meta().HasMore,
+	}
+	if response.HasMore && len(lineItems) > 0 {
+		response.NextCursor = lineItems[len(lineItems)-1].ID
+	}
+
+	return response, nil
+}
+
+// validateCheckoutSession applies the mode-specific line item and currency rules Stripe enforces
+func validateCheckoutSession(req *models.CreateCheckoutSessionRequest) error {
+	if req.Mode == string(stripe.CheckoutSessionModeSetup) {
+		if len(req.LineItems) > 0 {
+			return newValidationError("line_items", "are not allowed in setup mode")
+		}
+		// Without line items Stripe cannot infer the currency of the payment methods to offer
+		if req.Currency == "" {
+			return newValidationError("currency", "is required in setup mode")
+		}
+		return validateCurrency("currency", req.Currency)
+	}
+
+	if len(req.LineItems) == 0 {
+		return newValidationError("line_items", "must contain at least one item in %s mode", req.Mode)
+	}
+
+	return nil
+}
+
+// validateCheckoutCurrency checks that every line item can be charged in the session
+// currency. Checkout charges a price's currency_options amount when the session
+// currency is not the price's default currency.
+func (s *StripeService) validateCheckoutCurrency(ctx context.Context, req *models.CreateCheckoutSessionRequest) error {
+	for _, item := range req.LineItems {
+		if item.PriceData == nil {
+			continue
+		}
+
+		if err := validateCurrency("line_items", item.PriceData.Currency); err != nil {
+			return err
+		}
+
+		if req.Currency != "" && !strings.EqualFold(item.PriceData.Currency, req.Currency) {
+			return newValidationError("line_items", "price_data currency %s does not match the session currency %s", item.PriceData.Currency, req.Currency)
+		}
+	}
+
+	if req.Currency == "" {
+		return nil
+	}
+
+	if err := validateCurrency("currency", req.Currency); err != nil {
+		return err
+	}
+
+	for _, item := range req.LineItems {
+		if item.PriceID == "" {
+			continue
+		}
+
+		stripePrice, err := s.getPriceWithCurrencyOptions(ctx, item.PriceID)
+		if err != nil {
+			return fmt.Errorf("failed to create checkout session: %w", err)
+		}
+
+		if _, err := unitAmountForCurrency(stripePrice, req.Currency); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildCheckoutLineItemParams maps a line item onto Stripe params
+func buildCheckoutLineItemParams(item models.CheckoutLineItem) *stripe.CheckoutSessionLineItemParams {
+	params := &stripe.CheckoutSessionLineItemParams{
+		Quantity: stripe.Int64(item.Quantity),
+	}
+
+	if item.PriceID != "" {
+		params.Price = stripe.String(item.PriceID)
+		return params
+	}
+
+	priceData := item.PriceData
+	params.PriceData = &stripe.CheckoutSessionLineItemPriceDataParams{
+		Currency:   stripe.String(priceData.Currency),
+		UnitAmount: stripe.Int64(priceData.UnitAmount),
+	}
+
+	if priceData.ProductID != "" {
+		params.PriceData.Product = stripe.String(priceData.ProductID)
+	} else {
+		params.PriceData.ProductData = &stripe.CheckoutSessionLineItemPriceDataProductDataParams{
+			Name: stripe.String(priceData.ProductName),
+		}
+	}
+
+	if priceData.Recurring != nil {
+		params.PriceData.Recurring = &stripe.CheckoutSessionLineItemPriceDataRecurringParams{
+			Interval: stripe.String(priceData.Recurring.Interval),
+		}
+		if priceData.Recurring.IntervalCount > 0 {
+			params.PriceData.Recurring.IntervalCount = stripe.Int64(priceData.Recurring.IntervalCount)
+		}
+	}
+
+	return params
+}
+
+func (s *StripeService) convertStripeCheckoutSession(stripeSession *stripe.CheckoutSession) *models.CheckoutSession {
+	if stripeSession == nil {
+		return nil
+	}
+
+	session := &models.CheckoutSession{
+		ID:             stripeSession.ID,
+		URL:            stripeSession.URL,
+		Mode:           string(stripeSession.Mode),
+		Status:         string(stripeSession.Status),
+		PaymentStatus:  string(stripeSession.PaymentStatus),
+		CustomerEmail:  stripeSession.CustomerEmail,
+		Currency:       string(stripeSession.Currency),
+		AmountSubtotal: stripeSession.AmountSubtotal,
+		AmountTotal:    stripeSession.AmountTotal,
+		SuccessURL:     stripeSession.SuccessURL,
+		CancelURL:      stripeSession.CancelURL,
+		Metadata:       stripeSession.Metadata,
+		ExpiresAt:      time.Unix(stripeSession.ExpiresAt, 0),
+		CreatedAt:      time.Unix(stripeSession.Created, 0),
+	}
+
+	if stripeSession.Customer != nil {
+		session.CustomerID = stripeSession.Customer.ID
+	}
+
+	if stripeSession.PaymentIntent != nil {
+		session.PaymentIntentID = stripeSession.PaymentIntent.ID
+	}
+
+	if stripeSession.Subscription != nil {
+		session.SubscriptionID = stripeSession.Subscription.ID
+	}
+
+	if stripeSession.SetupIntent != nil {
+		session.SetupIntentID = stripeSession.SetupIntent.ID
+	}
+
+	return session
+}
+
+func convertStripeLineItem(lineItem *stripe.LineItem) models.CheckoutLineItemSummary {
+	summary := models.CheckoutLineItemSummary{
+		ID:             lineItem.ID,
+		Description:    lineItem.Description,
+		Quantity:       lineItem.Quantity,
+		Currency:       string(lineItem.Currency),
+		AmountSubtotal: lineItem.AmountSubtotal,
+		AmountDiscount: lineItem.AmountDiscount,
+		AmountTax:      lineItem.AmountTax,
+		AmountTotal:    lineItem.AmountTotal,
+	}
+
+	if lineItem.Price != nil {
+		summary.PriceID = lineItem.Price.ID
+	}
+
+	return summary
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"stripe-service/config"
+	"stripe-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v76"
+)
+
+func TestStripeService_CheckoutSessions(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+	ctx := context.Background()
+
+	// These will fail with the test key, but we're testing the methods exist and handle errors
+	session, err := service.CreateCheckoutSession(ctx, &models.CreateCheckoutSessionRequest{
+		Mode:       "payment",
+		LineItems:  []models.CheckoutLineItem{{PriceID: "price_test_123", Quantity: 1}},
+		SuccessURL: "https://example.com/success",
+	})
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, session, "Expected nil result on error")
+
+	session, err = service.GetCheckoutSession(ctx, "cs_test_123")
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, session, "Expected nil result on error")
+
+	_, err = service.ListCheckoutLineItems(ctx, &models.ListCheckoutLineItemsRequest{SessionID: "cs_test_123"})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to list checkout line items")
+}
+
+func TestValidateCheckoutSession(t *testing.T) {
+	lineItems := []models.CheckoutLineItem{{PriceID: "price_123", Quantity: 1}}
+
+	assert.NoError(t, validateCheckoutSession(&models.CreateCheckoutSessionRequest{Mode: "payment", LineItems: lineItems}))
+	assert.NoError(t, validateCheckoutSession(&models.CreateCheckoutSessionRequest{Mode: "setup", Currency: "usd"}))
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, validateCheckoutSession(&models.CreateCheckoutSessionRequest{Mode: "subscription"}), &validationErr)
+	assert.Equal(t, "line_items", validationErr.Field)
+	require.ErrorAs(t, validateCheckoutSession(&models.CreateCheckoutSessionRequest{Mode: "setup", Currency: "usd", LineItems: lineItems}), &validationErr)
+	assert.Equal(t, "line_items", validationErr.Field)
+
+	require.ErrorAs(t, validateCheckoutSession(&models.CreateCheckoutSessionRequest{Mode: "setup"}), &validationErr)
+	assert.Equal(t, "currency", validationErr.Field)
+	require.ErrorAs(t, validateCheckoutSession(&models.CreateCheckoutSessionRequest{Mode: "setup", Currency: "xyz"}), &validationErr)
+	assert.Equal(t, "currency", validationErr.Field)
+}
+
+func TestValidateCheckoutCurrency(t *testing.T) {
+	service := &StripeService{}
+	ctx := context.Background()
+	inline := func(currency string) models.CheckoutLineItem {
+		return models.CheckoutLineItem{
+			PriceData: &models.CheckoutPriceData{Currency: currency, UnitAmount: 990, ProductName: "Gift card"},
+			Quantity:  1,
+		}
+	}
+
+	assert.NoError(t, service.validateCheckoutCurrency(ctx, &models.CreateCheckoutSessionRequest{
+		Mode:      "payment",
+		Currency:  "eur",
+		LineItems: []models.CheckoutLineItem{inline("EUR")},
+	}))
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, service.validateCheckoutCurrency(ctx, &models.CreateCheckoutSessionRequest{
+		Mode:      "payment",
+		Currency:  "gbp",
+		LineItems: []models.CheckoutLineItem{inline("eur")},
+	}), &validationErr)
+	assert.Equal(t, "line_items", validationErr.Field)
+
+	require.ErrorAs(t, service.validateCheckoutCurrency(ctx, &models.CreateCheckoutSessionRequest{
+		Mode:      "payment",
+		LineItems: []models.CheckoutLineItem{inline("abc")},
+	}), &validationErr)
+	assert.Equal(t, "line_items", validationErr.Field)
+
+	require.ErrorAs(t, service.validateCheckoutCurrency(ctx, &models.CreateCheckoutSessionRequest{
+		Mode:     "setup",
+		Currency: "xyz",
+	}), &validationErr)
+	assert.Equal(t, "currency", validationErr.Field)
+}
+
+func TestBuildCheckoutLineItemParams(t *testing.T) {
+	byPrice := buildCheckoutLineItemParams(models.CheckoutLineItem{PriceID: "price_123", Quantity: 2})
+	assert.Equal(t, "price_123", *byPrice.Price)
+	assert.Equal(t, int64(2), *byPrice.Quantity)
+	assert.Nil(t, byPrice.PriceData)
+
+	inline := buildCheckoutLineItemParams(models.CheckoutLineItem{
+		PriceData: &models.CheckoutPriceData{
+			Currency:    "eur",
+			UnitAmount:  990,
+			ProductName: "Gift card",
+			Recurring:   &models.RecurringPriceData{Interval: "month", IntervalCount: 3},
+		},
+		Quantity: 1,
+	})
+	require.NotNil(t, inline.PriceData)
+	assert.Nil(t, inline.Price)
+	assert.Equal(t, "eur", *inline.PriceData.Currency)
+	assert.Equal(t, int64(990), *inline.PriceData.UnitAmount)
+	assert.Equal(t, "Gift card", *inline.PriceData.ProductData.Name)
+	assert.Nil(t, inline.PriceData.Product)
+	assert.Equal(t, "month", *inline.PriceData.Recurring.Interval)
+	assert.Equal(t, int64(3), *inline.PriceData.Recurring.IntervalCount)
+}
+
+func TestConvertStripeCheckoutSession(t *testing.T) {
+	service := &StripeService{}
+
+	assert.Nil(t, service.convertStripeCheckoutSession(nil))
+
+	result := service.convertStripeCheckoutSession(&stripe.CheckoutSession{
+		ID:            "cs_123",
+		URL:           "https://checkout.stripe.com/c/pay/cs_123",
+		Mode:          stripe.CheckoutSessionModeSubscription,
+		Status:        stripe.CheckoutSessionStatusComplete,
+		PaymentStatus: stripe.CheckoutSessionPaymentStatusPaid,
+		Customer:      &stripe.Customer{ID: "cus_123"},
+		Subscription:  &stripe.Subscription{ID: "sub_123"},
+		AmountTotal:   1500,
+		ExpiresAt:     1641081600,
+		Created:       1640995200,
+	})
+
+	assert.Equal(t, "cs_123", result.ID)
+	assert.Equal(t, "https://checkout.stripe.com/c/pay/cs_123", result.URL)
+	assert.Equal(t, "subscription", result.Mode)
+	assert.Equal(t, "complete", result.Status)
+	assert.Equal(t, "paid", result.PaymentStatus)
+	assert.Equal(t, "cus_123", result.CustomerID)
+	assert.Equal(t, "sub_123", result.SubscriptionID)
+	assert.Equal(t, int64(1500), result.AmountTotal)
+	assert.Equal(t, time.Unix(1641081600, 0), result.ExpiresAt)
+}
@@ -0,0 +1,30 @@
+package service
+
+import "strings"
+
+// isoCurrencies holds the active ISO 4217 currency codes in Stripe's lowercase form
+var isoCurrencies = toCurrencySet(
+	"aed afn all amd ang aoa ars aud awg azn bam bbd bdt bgn bhd bif bmd bnd bob bov brl bsd btn bwp " +
+		"byn bzd cad cdf che chf chw clf clp cny cop cou crc cuc cup cve czk djf dkk dop dzd egp ern etb " +
+		"eur fjd fkp gbp gel ghs gip gmd gnf gtq gyd hkd hnl htg huf idr ils inr iqd irr isk jmd jod jpy " +
+		"kes kgs khr kmf kpw krw kwd kyd kzt lak lbp lkr lrd lsl lyd mad mdl mga mkd mmk mnt mop mru mur " +
+		"mvr mwk mxn mxv myr mzn nad ngn nio nok npr nzd omr pab pen pgk php pkr pln pyg qar ron rsd rub " +
+		"rwf sar sbd scr sdg sek sgd shp sle sll sos srd ssp stn svc syp szl thb tjs tmt tnd top try ttd " +
+		"twd tzs uah ugx usd usn uyi uyu uyw uzs ved ves vnd vuv wst xaf xcd xof xpf yer zar zmw zwl",
+)
+
+func toCurrencySet(codes string) map[string]bool {
+	set := make(map[string]bool)
+	for _, code := range strings.Fields(codes) {
+		set[code] = true
+	}
+	return set
+}
+
+// validateCurrency rejects codes that are not ISO 4217 currencies
+func validateCurrency(field, currency string) error {
+	if !isoCurrencies[strings.ToLower(currency)] {
+		return newValidationError(field, "%q is not an ISO 4217 currency code", currency)
+	}
+	return nil
+}
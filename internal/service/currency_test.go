@@ -0,0 +1,20 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCurrency(t *testing.T) {
+	for _, currency := range []string{"usd", "EUR", "gbp", "jpy"} {
+		assert.NoError(t, validateCurrency("currency", currency), currency)
+	}
+
+	for _, currency := range []string{"abc", "xyz", "us", ""} {
+		var validationErr *ValidationError
+		require.ErrorAs(t, validateCurrency("currency", currency), &validationErr, currency)
+		assert.Equal(t, "currency", validationErr.Field)
+	}
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"stripe-service/internal/models"
+
+	"github.com/stripe/stripe-go/v76"
+)
+
+// Dispute operations
+
+// GetDispute retrieves a dispute by ID
+func (s *StripeService) GetDispute(ctx context.Context, disputeID string) (*models.Dispute, error) {
+	params := &stripe.DisputeParams{}
+	params.Context = ctx
+
+	stripeDispute, err := s.client.Disputes.Get(disputeID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dispute: %w", err)
+	}
+
+	return s.convertStripeDispute(stripeDispute), nil
+}
+
+// ListDisputes lists a single page of disputes
+func (s *StripeService) ListDisputes(ctx context.Context, req *models.ListDisputesRequest) (*models.ListDisputesResponse, error) {
+	params := &stripe.DisputeListParams{}
+	params.Context = ctx
+	params.Single = true
+	params.Limit = stripe.Int64(pageLimit(req.Limit))
+
+	if req.Cursor != "" {
+		params.StartingAfter = stripe.String(req.Cursor)
+	}
+
+	if req.PaymentIntentID != "" {
+		params.PaymentIntent = stripe.String(req.PaymentIntentID)
+	}
+
+	if req.CreatedGte > 0 || req.CreatedLte > 0 {
+		params.CreatedRange = &stripe.RangeQueryParams{
+			GreaterThanOrEqual: req.CreatedGte,
+			LesserThanOrEqual:  req.CreatedLte,
+		}
+	}
+
+	iter := s.client.Disputes.List(params)
+	disputes := []models.Dispute{}
+
+	for iter.Next() {
+		disputes = append(disputes, *s.convertStripeDispute(iter.Dispute()))
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list disputes: %w", err)
+	}
+
+	response := &models.ListDisputesResponse{
+		Disputes: disputes,
+		HasMore:  iter.Meta().HasMore,
+	}
+	if response.HasMore && len(disputes) > 0 {
+		response.NextCursor = disputes[len(disputes)-1].ID
+	}
+
+	return response, nil
+}
+
+// SubmitDisputeEvidence stages evidence on a dispute, and submits it to the card network
+// when requested
+func (s *StripeService) SubmitDisputeEvidence(ctx context.Context, disputeID string, req *models.SubmitDisputeEvidenceRequest) (*models.Dispute, error) {
+	params := &stripe.DisputeParams{
+		Evidence: buildDisputeEvidenceParams(&req.Evidence),
+		Submit:   stripe.Bool(req.Submit),
+	}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	if req.Metadata != nil {
+		params.Metadata = req.Metadata
+	}
+
+	stripeDispute, err := s.client.Disputes.Update(disputeID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit dispute evidence: %w", err)
+	}
+
+	return s.convertStripeDispute(stripeDispute), nil
+}
+
+// CloseDispute accepts a dispute, conceding it as lost
+func (s *StripeService) CloseDispute(ctx context.Context, disputeID string) (*models.Dispute, error) {
+	params := &stripe.DisputeParams{}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	stripeDispute, err := s.client.Disputes.Close(disputeID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to close dispute: %w", err)
+	}
+
+	return s.convertStripeDispute(stripeDispute), nil
+}
+
+// buildDisputeEvidenceParams maps the evidence fields that were provided onto Stripe params
+func buildDisputeEvidenceParams(evidence *models.DisputeEvidence) *stripe.DisputeEvidenceParams {
+	return &stripe.DisputeEvidenceParams{
+		AccessActivityLog:            optionalString(evidence.AccessActivityLog),
+		BillingAddress:               optionalString(evidence.BillingAddress),
+		CancellationPolicy:           optionalString(evidence.CancellationPolicy),
+		CancellationPolicyDisclosure: optionalString(evidence.CancellationPolicyDisclosure),
+		CancellationRebuttal:         optionalString(evidence.CancellationRebuttal),
+		CustomerCommunication:        optionalString(evidence.CustomerCommunication),
+		CustomerEmailAddress:         optionalString(evidence.CustomerEmailAddress),
+		CustomerName:                 optionalString(evidence.CustomerName),
+		CustomerPurchaseIP:           optionalString(evidence.CustomerPurchaseIP),
+		CustomerSignature:            optionalString(evidence.CustomerSignature),
+		DuplicateChargeDocumentation: optionalString(evidence.DuplicateChargeDocumentation),
+		DuplicateChargeExplanation:   optionalString(evidence.DuplicateChargeExplanation),
+		DuplicateChargeID:            optionalString(evidence.DuplicateChargeID),
+		ProductDescription:           optionalString(evidence.ProductDescription),
+		Receipt:                      optionalString(evidence.Receipt),
+		RefundPolicy:                 optionalString(evidence.RefundPolicy),
+		RefundPolicyDisclosure:       optionalString(evidence.RefundPolicyDisclosure),
+		RefundRefusalExplanation:     optionalString(evidence.RefundRefusalExplanation),
+		ServiceDate:                  optionalString(evidence.ServiceDate),
+		ServiceDocumentation:         optionalString(evidence.ServiceDocumentation),
+		ShippingAddress:              optionalString(evidence.ShippingAddress),
+		ShippingCarrier:              optionalString(evidence.ShippingCarrier),
+		ShippingDate:                 optionalString(evidence.ShippingDate),
+		ShippingDocumentation:        optionalString(evidence.ShippingDocumentation),
+		ShippingTrackingNumber:       optionalString(evidence.ShippingTrackingNumber),
+		UncategorizedFile:            optionalString(evidence.UncategorizedFile),
+		UncategorizedText:            optionalString(evidence.UncategorizedText),
+	}
+}
+
+// optionalString returns nil for empty values so they are left out of the request
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return stripe.String(value)
+}
+
+// fileID returns the ID of an optional file reference
+func fileID(file *stripe.File) string {
+	if file == nil {
+		return ""
+	}
+	return file.ID
+}
+
+func (s *StripeService) convertStripeDispute(stripeDispute *stripe.Dispute) *models.Dispute {
+	if stripeDispute == nil {
+		return nil
+	}
+
+	dispute := &models.Dispute{
+		ID:                 stripeDispute.ID,
+		Amount:             stripeDispute.Amount,
+		Currency:           string(stripeDispute.Currency),
+		Status:             string(stripeDispute.Status),
+		Reason:             string(stripeDispute.Reason),
+		IsChargeRefundable: stripeDispute.IsChargeRefundable,
+		Metadata:           stripeDispute.Metadata,
+		CreatedAt:          time.Unix(stripeDispute.Created, 0),
+	}
+
+	if stripeDispute.Charge != nil {
+		dispute.ChargeID = stripeDispute.Charge.ID
+	}
+
+	if stripeDispute.PaymentIntent != nil {
+		dispute.PaymentIntentID = stripeDispute.PaymentIntent.ID
+	}
+
+	if details := stripeDispute.EvidenceDetails; details != nil {
+		dispute.EvidenceDueBy = optionalTime(details.DueBy)
+		dispute.HasEvidence = details.HasEvidence
+		dispute.PastDue = details.PastDue
+		dispute.SubmissionCount = details.SubmissionCount
+	}
+
+	if evidence := stripeDispute.Evidence; evidence != nil {
+		dispute.Evidence = &models.DisputeEvidence{
+			AccessActivityLog:            evidence.AccessActivityLog,
+			BillingAddress:               evidence.BillingAddress,
+			CancellationPolicy:           fileID(evidence.CancellationPolicy),
+			CancellationPolicyDisclosure: evidence.CancellationPolicyDisclosure,
+			CancellationRebuttal:         evidence.CancellationRebuttal,
+			CustomerCommunication:        fileID(evidence.CustomerCommunication),
+			CustomerEmailAddress:         evidence.CustomerEmailAddress,
+			CustomerName:                 evidence.CustomerName,
+			CustomerPurchaseIP:           evidence.CustomerPurchaseIP,
+			CustomerSignature:            fileID(evidence.CustomerSignature),
+			DuplicateChargeDocumentation: fileID(evidence.DuplicateChargeDocumentation),
+			DuplicateChargeExplanation:   evidence.DuplicateChargeExplanation,
+			DuplicateChargeID:            evidence.DuplicateChargeID,
+			ProductDescription:           evidence.ProductDescription,
+			Receipt:                      fileID(evidence.Receipt),
+			RefundPolicy:                 fileID(evidence.RefundPolicy),
+			RefundPolicyDisclosure:       evidence.RefundPolicyDisclosure,
+			RefundRefusalExplanation:     evidence.RefundRefusalExplanation,
+			ServiceDate:                  evidence.ServiceDate,
+			ServiceDocumentation:         fileID(evidence.ServiceDocumentation),
+			ShippingAddress:              evidence.ShippingAddress,
+			ShippingCarrier:              evidence.ShippingCarrier,
+			ShippingDate:                 evidence.ShippingDate,
+			ShippingDocumentation:        fileID(evidence.ShippingDocumentation),
+			ShippingTrackingNumber:       evidence.ShippingTrackingNumber,
+			UncategorizedFile:            fileID(evidence.UncategorizedFile),
+			UncategorizedText:            evidence.UncategorizedText,
+		}
+	}
+
+	return dispute
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"stripe-service/config"
+	"stripe-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v76"
+)
+
+func TestStripeService_Disputes(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+	ctx := context.Background()
+
+	// These will fail with the test key, but we're testing the methods exist and handle errors
+	dispute, err := service.GetDispute(ctx, "dp_test_123")
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, dispute, "Expected nil result on error")
+
+	dispute, err = service.SubmitDisputeEvidence(ctx, "dp_test_123", &models.SubmitDisputeEvidenceRequest{
+		Evidence: models.DisputeEvidence{ProductDescription: "Annual plan"},
+	})
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, dispute, "Expected nil result on error")
+
+	dispute, err = service.CloseDispute(ctx, "dp_test_123")
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, dispute, "Expected nil result on error")
+
+	_, err = service.ListDisputes(ctx, &models.ListDisputesRequest{})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to list disputes")
+}
+
+func TestBuildDisputeEvidenceParams(t *testing.T) {
+	params := buildDisputeEvidenceParams(&models.DisputeEvidence{
+		ProductDescription: "Annual plan",
+		Receipt:            "file_123",
+	})
+
+	require.NotNil(t, params.ProductDescription)
+	assert.Equal(t, "Annual plan", *params.ProductDescription)
+	require.NotNil(t, params.Receipt)
+	assert.Equal(t, "file_123", *params.Receipt)
+	assert.Nil(t, params.CustomerName, "Expected fields that were not provided to be omitted")
+}
+
+func TestConvertStripeDispute(t *testing.T) {
+	service := &StripeService{}
+
+	assert.Nil(t, service.convertStripeDispute(nil))
+
+	result := service.convertStripeDispute(&stripe.Dispute{
+		ID:            "dp_123",
+		Amount:        1000,
+		Currency:      stripe.CurrencyUSD,
+		Status:        stripe.DisputeStatusNeedsResponse,
+		Reason:        stripe.DisputeReasonFraudulent,
+		Charge:        &stripe.Charge{ID: "ch_123"},
+		PaymentIntent: &stripe.PaymentIntent{ID: "pi_123"},
+		EvidenceDetails: &stripe.DisputeEvidenceDetails{
+			DueBy:       1700000000,
+			HasEvidence: true,
+		},
+		Evidence: &stripe.DisputeEvidence{
+			ProductDescription: "Annual plan",
+			Receipt:            &stripe.File{ID: "file_123"},
+		},
+		Created: 1640995200,
+	})
+
+	assert.Equal(t, "dp_123", result.ID)
+	assert.Equal(t, "needs_response", result.Status)
+	assert.Equal(t, "fraudulent", result.Reason)
+	assert.Equal(t, "ch_123", result.ChargeID)
+	assert.Equal(t, "pi_123", result.PaymentIntentID)
+	require.NotNil(t, result.EvidenceDueBy)
+	assert.Equal(t, time.Unix(1700000000, 0), *result.EvidenceDueBy)
+	assert.True(t, result.HasEvidence)
+	require.NotNil(t, result.Evidence)
+	assert.Equal(t, "Annual plan", result.Evidence.ProductDescription)
+	assert.Equal(t, "file_123", result.Evidence.Receipt)
+}
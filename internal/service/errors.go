@@ -0,0 +1,38 @@
+package service
+
+import "fmt"
+
+// ValidationError reports a request that Stripe would reject, detected before calling the API.
+// Handlers surface it to clients as a 400 Bad Request.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+// newValidationError creates a ValidationError for the given request field
+func newValidationError(field, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// PartialFailureError reports an operation that failed after an earlier step already took
+// effect in Stripe. The service returns the partially applied result alongside it, and
+// retrying the whole operation is safe.
+type PartialFailureError struct {
+	Step string
+	Err  error
+}
+
+func (e *PartialFailureError) Error() string {
+	return fmt.Sprintf("partially completed, %s failed: %v", e.Step, e.Err)
+}
+
+func (e *PartialFailureError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationError(t *testing.T) {
+	err := newValidationError("amount_to_capture", "%d exceeds the authorized amount of %d", 1500, 1000)
+
+	assert.Equal(t, "amount_to_capture 1500 exceeds the authorized amount of 1000", err.Error())
+
+	var validationErr *ValidationError
+	assert.True(t, errors.As(fmt.Errorf("wrapped: %w", err), &validationErr), "Expected ValidationError to survive wrapping")
+}
+
+func TestPartialFailureError(t *testing.T) {
+	cause := errors.New("card declined")
+	err := &PartialFailureError{Step: "set_as_default", Err: cause}
+
+	assert.Equal(t, "partially completed, set_as_default failed: card declined", err.Error())
+	assert.ErrorIs(t, err, cause)
+
+	var partialErr *PartialFailureError
+	assert.True(t, errors.As(fmt.Errorf("wrapped: %w", err), &partialErr), "Expected PartialFailureError to survive wrapping")
+}
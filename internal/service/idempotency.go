@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"github.com/stripe/stripe-go/v76"
+)
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context carrying the client-supplied Idempotency-Key,
+// which write operations forward to Stripe
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	if key == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the Idempotency-Key stored in the context, if any
+func IdempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// setIdempotencyKey forwards the request's Idempotency-Key to Stripe
+func setIdempotencyKey(ctx context.Context, params *stripe.Params) {
+	if key := IdempotencyKeyFromContext(ctx); key != "" {
+		params.SetIdempotencyKey(key)
+	}
+}
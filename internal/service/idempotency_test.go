@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stripe/stripe-go/v76"
+)
+
+func TestIdempotencyKeyContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "", IdempotencyKeyFromContext(ctx))
+	assert.Equal(t, ctx, WithIdempotencyKey(ctx, ""), "Expected empty key to leave context unchanged")
+
+	ctx = WithIdempotencyKey(ctx, "key-123")
+	assert.Equal(t, "key-123", IdempotencyKeyFromContext(ctx))
+}
+
+func TestSetIdempotencyKey(t *testing.T) {
+	params := &stripe.CustomerParams{}
+	setIdempotencyKey(context.Background(), &params.Params)
+	assert.Nil(t, params.IdempotencyKey, "Expected no key without one in context")
+
+	setIdempotencyKey(WithIdempotencyKey(context.Background(), "key-123"), &params.Params)
+	if assert.NotNil(t, params.IdempotencyKey) {
+		assert.Equal(t, "key-123", *params.IdempotencyKey)
+	}
+}
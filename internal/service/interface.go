@@ -10,10 +10,61 @@ type StripeServiceInterface interface {
 	CreateCustomer(ctx context.Context, req *models.CreateCustomerRequest) (*models.Customer, error)
 	GetCustomer(ctx context.Context, customerID string) (*models.Customer, error)
 	ListCustomers(ctx context.Context, req *models.ListCustomersRequest) (*models.ListCustomersResponse, error)
+	UpdateCustomer(ctx context.Context, customerID string, req *models.UpdateCustomerRequest) (*models.Customer, error)
+	DeleteCustomer(ctx context.Context, customerID string) (*models.DeletedCustomer, error)
+	ListPaymentMethods(ctx context.Context, req *models.ListPaymentMethodsRequest) (*models.ListPaymentMethodsResponse, error)
+	GetPaymentMethod(ctx context.Context, paymentMethodID string) (*models.PaymentMethod, error)
+	AttachPaymentMethod(ctx context.Context, paymentMethodID string, req *models.AttachPaymentMethodRequest) (*models.PaymentMethod, error)
+	DetachPaymentMethod(ctx context.Context, paymentMethodID string) (*models.PaymentMethod, error)
+	SetDefaultPaymentMethod(ctx context.Context, customerID string, req *models.SetDefaultPaymentMethodRequest) (*models.Customer, error)
 	CreatePaymentIntent(ctx context.Context, req *models.CreatePaymentIntentRequest) (*models.PaymentIntent, error)
 	ConfirmPaymentIntent(ctx context.Context, paymentIntentID string, req *models.ConfirmPaymentIntentRequest) (*models.PaymentIntent, error)
+	GetPaymentIntent(ctx context.Context, paymentIntentID string) (*models.PaymentIntent, error)
+	UpdatePaymentIntent(ctx context.Context, paymentIntentID string, req *models.UpdatePaymentIntentRequest) (*models.PaymentIntent, error)
+	CancelPaymentIntent(ctx context.Context, paymentIntentID string, req *models.CancelPaymentIntentRequest) (*models.PaymentIntent, error)
+	CapturePaymentIntent(ctx context.Context, paymentIntentID string, req *models.CapturePaymentIntentRequest) (*models.PaymentIntent, error)
+	ListPaymentIntents(ctx context.Context, req *models.ListPaymentIntentsRequest) (*models.ListPaymentIntentsResponse, error)
+	CreateSetupIntent(ctx context.Context, req *models.CreateSetupIntentRequest) (*models.SetupIntent, error)
+	GetSetupIntent(ctx context.Context, setupIntentID string) (*models.SetupIntent, error)
+	ConfirmSetupIntent(ctx context.Context, setupIntentID string, req *models.ConfirmSetupIntentRequest) (*models.SetupIntent, error)
+	CancelSetupIntent(ctx context.Context, setupIntentID string, req *models.CancelSetupIntentRequest) (*models.SetupIntent, error)
+	CreateCheckoutSession(ctx context.Context, req *models.CreateCheckoutSessionRequest) (*models.CheckoutSession, error)
+	GetCheckoutSession(ctx context.Context, sessionID string) (*models.CheckoutSession, error)
+	ListCheckoutLineItems(ctx context.Context, req *models.ListCheckoutLineItemsRequest) (*models.ListCheckoutLineItemsResponse, error)
+	CreatePortalSession(ctx context.Context, customerID string, req *models.CreatePortalSessionRequest) (*models.PortalSession, error)
+	CreatePortalConfiguration(ctx context.Context, req *models.CreatePortalConfigurationRequest) (*models.PortalConfiguration, error)
+	GetPortalConfiguration(ctx context.Context, configurationID string) (*models.PortalConfiguration, error)
+	UpdatePortalConfiguration(ctx context.Context, configurationID string, req *models.UpdatePortalConfigurationRequest) (*models.PortalConfiguration, error)
+	CreateRefund(ctx context.Context, req *models.CreateRefundRequest) (*models.Refund, error)
+	GetRefund(ctx context.Context, refundID string) (*models.Refund, error)
+	ListRefunds(ctx context.Context, req *models.ListRefundsRequest) (*models.ListRefundsResponse, error)
+	CancelRefund(ctx context.Context, refundID string) (*models.Refund, error)
+	GetDispute(ctx context.Context, disputeID string) (*models.Dispute, error)
+	ListDisputes(ctx context.Context, req *models.ListDisputesRequest) (*models.ListDisputesResponse, error)
+	SubmitDisputeEvidence(ctx context.Context, disputeID string, req *models.SubmitDisputeEvidenceRequest) (*models.Dispute, error)
+	CloseDispute(ctx context.Context, disputeID string) (*models.Dispute, error)
 	CreateProduct(ctx context.Context, req *models.CreateProductRequest) (*models.Product, error)
+	GetProduct(ctx context.Context, productID string) (*models.Product, error)
+	ListProducts(ctx context.Context, req *models.ListProductsRequest) (*models.ListProductsResponse, error)
+	UpdateProduct(ctx context.Context, productID string, req *models.UpdateProductRequest) (*models.Product, error)
+	ArchiveProduct(ctx context.Context, productID string) (*models.Product, error)
+	DeleteProduct(ctx context.Context, productID string) (*models.DeletedProduct, error)
 	CreatePrice(ctx context.Context, req *models.CreatePriceRequest) (*models.Price, error)
+	GetPrice(ctx context.Context, priceID string) (*models.Price, error)
+	ListPrices(ctx context.Context, req *models.ListPricesRequest) (*models.ListPricesResponse, error)
+	UpdatePrice(ctx context.Context, priceID string, req *models.UpdatePriceRequest) (*models.Price, error)
 	CreateSubscription(ctx context.Context, req *models.CreateSubscriptionRequest) (*models.Subscription, error)
-	CancelSubscription(ctx context.Context, subscriptionID string) (*models.Subscription, error)
+	CancelSubscription(ctx context.Context, subscriptionID string, req *models.CancelSubscriptionRequest) (*models.Subscription, error)
+	UndoSubscriptionCancellation(ctx context.Context, subscriptionID string) (*models.Subscription, error)
+	GetSubscription(ctx context.Context, subscriptionID string) (*models.Subscription, error)
+	ListSubscriptions(ctx context.Context, req *models.ListSubscriptionsRequest) (*models.ListSubscriptionsResponse, error)
+	UpdateSubscription(ctx context.Context, subscriptionID string, req *models.UpdateSubscriptionRequest) (*models.Subscription, error)
+	ChangeSubscriptionPlan(ctx context.Context, subscriptionID string, req *models.ChangePlanRequest) (*models.Subscription, error)
+	PreviewSubscriptionPlanChange(ctx context.Context, subscriptionID string, req *models.ChangePlanRequest) (*models.PlanChangePreview, error)
+	AddSubscriptionItem(ctx context.Context, subscriptionID string, req *models.AddSubscriptionItemRequest) (*models.SubscriptionItem, error)
+	UpdateSubscriptionItem(ctx context.Context, subscriptionID, itemID string, req *models.UpdateSubscriptionItemRequest) (*models.SubscriptionItem, error)
+	RemoveSubscriptionItem(ctx context.Context, subscriptionID, itemID string, req *models.RemoveSubscriptionItemRequest) (*models.DeletedSubscriptionItem, error)
+	EndSubscriptionTrial(ctx context.Context, subscriptionID string) (*models.Subscription, error)
+	PauseSubscription(ctx context.Context, subscriptionID string, req *models.PauseSubscriptionRequest) (*models.Subscription, error)
+	ResumeSubscription(ctx context.Context, subscriptionID string) (*models.Subscription, error)
 }
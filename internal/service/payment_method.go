@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"stripe-service/internal/models"
+
+	"github.com/stripe/stripe-go/v76"
+)
+
+// Payment method operations
+
+// GetPaymentMethod retrieves a payment method by ID
+func (s *StripeService) GetPaymentMethod(ctx context.Context, paymentMethodID string) (*models.PaymentMethod, error) {
+	params := &stripe.PaymentMethodParams{}
+	params.Context = ctx
+
+	stripePM, err := s.client.PaymentMethods.Get(paymentMethodID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment method: %w", err)
+	}
+
+	return s.convertStripePaymentMethod(stripePM), nil
+}
+
+// ListPaymentMethods lists a single page of a customer's payment methods
+func (s *StripeService) ListPaymentMethods(ctx context.Context, req *models.ListPaymentMethodsRequest) (*models.ListPaymentMethodsResponse, error) {
+	params := &stripe.PaymentMethodListParams{
+		Customer: stripe.String(req.CustomerID),
+	}
+	params.Context = ctx
+	params.Single = true
+	params.Limit = stripe.Int64(pageLimit(req.Limit))
+
+	if req.Type != "" {
+		params.Type = stripe.String(req.Type)
+	}
+
+	if req.Cursor != "" {
+		params.StartingAfter = stripe.String(req.Cursor)
+	}
+
+	iter := s.client.PaymentMethods.List(params)
+	paymentMethods := []models.PaymentMethod{}
+
+	for iter.Next() {
+		paymentMethods = append(paymentMethods, *s.convertStripePaymentMethod(iter.PaymentMethod()))
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list payment methods: %w", err)
+	}
+
+	response := &models.ListPaymentMethodsResponse{
+		PaymentMethods: paymentMethods,
+		HasMore:        iter.Meta().HasMore,
+	}
+	if response.HasMore && len(paymentMethods) > 0 {
+		response.NextCursor = paymentMethods[len(paymentMethods)-1].ID
+	}
+
+	return response, nil
+}
+
+// AttachPaymentMethod attaches a payment method to a customer, optionally making it
+// the customer's default. If making it the default fails after the attach succeeded, the
+// attached payment method is returned together with a *PartialFailureError.
+func (s *StripeService) AttachPaymentMethod(ctx context.Context, paymentMethodID string, req *models.AttachPaymentMethodRequest) (*models.PaymentMethod, error) {
+	params := &stripe.PaymentMethodAttachParams{
+		Customer: stripe.String(req.CustomerID),
+	}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	stripePM, err := s.client.PaymentMethods.Attach(paymentMethodID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach payment method: %w", err)
+	}
+
+	if req.SetAsDefault {
+		if _, err := s.SetDefaultPaymentMethod(ctx, req.CustomerID, &models.SetDefaultPaymentMethodRequest{
+			PaymentMethodID: stripePM.ID,
+		}); err != nil {
+			return s.convertStripePaymentMethod(stripePM), &PartialFailureError{Step: "set_as_default", Err: err}
+		}
+	}
+
+	return s.convertStripePaymentMethod(stripePM), nil
+}
+
+// DetachPaymentMethod detaches a payment method from its customer
+func (s *StripeService) DetachPaymentMethod(ctx context.Context, paymentMethodID string) (*models.PaymentMethod, error) {
+	params := &stripe.PaymentMethodDetachParams{}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	stripePM, err := s.client.PaymentMethods.Detach(paymentMethodID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detach payment method: %w", err)
+	}
+
+	return s.convertStripePaymentMethod(stripePM), nil
+}
+
+// SetDefaultPaymentMethod sets invoice_settings.default_payment_method on a customer
+func (s *StripeService) SetDefaultPaymentMethod(ctx context.Context, customerID string, req *models.SetDefaultPaymentMethodRequest) (*models.Customer, error) {
+	params := &stripe.CustomerParams{
+		InvoiceSettings: &stripe.CustomerInvoiceSettingsParams{
+			DefaultPaymentMethod: stripe.String(req.PaymentMethodID),
+		},
+	}
+	params.Context = ctx
+
+	stripeCustomer, err := s.client.Customers.Update(customerID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set default payment method: %w", err)
+	}
+
+	return s.convertStripeCustomer(stripeCustomer), nil
+}
+
+func (s *StripeService) convertStripePaymentMethod(stripePM *stripe.PaymentMethod) *models.PaymentMethod {
+	if stripePM == nil {
+		return nil
+	}
+
+	paymentMethod := &models.PaymentMethod{
+		ID:        stripePM.ID,
+		Type:      string(stripePM.Type),
+		Metadata:  stripePM.Metadata,
+		CreatedAt: time.Unix(stripePM.Created, 0),
+	}
+
+	if stripePM.Customer != nil {
+		paymentMethod.CustomerID = stripePM.Customer.ID
+	}
+
+	if card := stripePM.Card; card != nil {
+		paymentMethod.Card = &models.PaymentCard{
+			Brand:    string(card.Brand),
+			Last4:    card.Last4,
+			ExpMonth: card.ExpMonth,
+			ExpYear:  card.ExpYear,
+			Funding:  string(card.Funding),
+			Country:  card.Country,
+		}
+		if card.Wallet != nil {
+			paymentMethod.Card.WalletType = string(card.Wallet.Type)
+		}
+	}
+
+	return paymentMethod
+}
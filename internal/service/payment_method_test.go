@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"stripe-service/config"
+	"stripe-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v76"
+)
+
+func TestStripeService_PaymentMethods(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+	ctx := context.Background()
+
+	// These will fail with the test key, but we're testing the methods exist and handle errors
+	paymentMethod, err := service.GetPaymentMethod(ctx, "pm_test_123")
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, paymentMethod, "Expected nil result on error")
+
+	paymentMethod, err = service.AttachPaymentMethod(ctx, "pm_test_123", &models.AttachPaymentMethodRequest{CustomerID: "cus_test_123"})
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, paymentMethod, "Expected nil result on error")
+
+	paymentMethod, err = service.DetachPaymentMethod(ctx, "pm_test_123")
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, paymentMethod, "Expected nil result on error")
+
+	customer, err := service.SetDefaultPaymentMethod(ctx, "cus_test_123", &models.SetDefaultPaymentMethodRequest{PaymentMethodID: "pm_test_123"})
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, customer, "Expected nil result on error")
+
+	_, err = service.ListPaymentMethods(ctx, &models.ListPaymentMethodsRequest{CustomerID: "cus_test_123"})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to list payment methods")
+}
+
+func TestConvertStripePaymentMethod(t *testing.T) {
+	service := &StripeService{}
+
+	assert.Nil(t, service.convertStripePaymentMethod(nil))
+
+	result := service.convertStripePaymentMethod(&stripe.PaymentMethod{
+		ID:       "pm_123",
+		Type:     stripe.PaymentMethodTypeCard,
+		Customer: &stripe.Customer{ID: "cus_123"},
+		Card: &stripe.PaymentMethodCard{
+			Brand:    stripe.PaymentMethodCardBrandVisa,
+			Last4:    "4242",
+			ExpMonth: 12,
+			ExpYear:  2030,
+			Funding:  stripe.CardFundingCredit,
+			Wallet:   &stripe.PaymentMethodCardWallet{Type: stripe.PaymentMethodCardWalletTypeApplePay},
+		},
+		Created: 1640995200,
+	})
+
+	assert.Equal(t, "pm_123", result.ID)
+	assert.Equal(t, "card", result.Type)
+	assert.Equal(t, "cus_123", result.CustomerID)
+	require.NotNil(t, result.Card)
+	assert.Equal(t, "visa", result.Card.Brand)
+	assert.Equal(t, "4242", result.Card.Last4)
+	assert.Equal(t, int64(12), result.Card.ExpMonth)
+	assert.Equal(t, int64(2030), result.Card.ExpYear)
+	assert.Equal(t, "credit", result.Card.Funding)
+	assert.Equal(t, "apple_pay", result.Card.WalletType)
+	assert.Equal(t, time.Unix(1640995200, 0), result.CreatedAt)
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"stripe-service/internal/models"
+
+	"github.com/stripe/stripe-go/v76"
+)
+
+// Customer portal operations
+
+// CreatePortalSession creates a customer portal session for a customer
+func (s *StripeService) CreatePortalSession(ctx context.Context, customerID string, req *models.CreatePortalSessionRequest) (*models.PortalSession, error) {
+	params := &stripe.BillingPortalSessionParams{
+		Customer:  stripe.String(customerID),
+		ReturnURL: stripe.String(req.ReturnURL),
+	}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	if req.ConfigurationID != "" {
+		params.Configuration = stripe.String(req.ConfigurationID)
+	}
+
+	stripeSession, err := s.client.BillingPortalSessions.New(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create portal session: %w", err)
+	}
+
+	session := &models.PortalSession{
+		ID:         stripeSession.ID,
+		URL:        stripeSession.URL,
+		CustomerID: stripeSession.Customer,
+		ReturnURL:  stripeSession.ReturnURL,
+		CreatedAt:  time.Unix(stripeSession.Created, 0),
+	}
+	if stripeSession.Configuration != nil {
+		session.ConfigurationID = stripeSession.Configuration.ID
+	}
+
+	return session, nil
+}
+
+// CreatePortalConfiguration creates a customer portal configuration
+func (s *StripeService) CreatePortalConfiguration(ctx context.Context, req *models.CreatePortalConfigurationRequest) (*models.PortalConfiguration, error) {
+	params := &stripe.BillingPortalConfigurationParams{
+		BusinessProfile: &stripe.BillingPortalConfigurationBusinessProfileParams{
+			Headline:          optionalString(req.Headline),
+			PrivacyPolicyURL:  optionalString(req.PrivacyPolicyURL),
+			TermsOfServiceURL: optionalString(req.TermsOfServiceURL),
+		},
+		DefaultReturnURL: optionalString(req.DefaultReturnURL),
+		Features:         buildPortalFeaturesParams(&req.Features, false),
+	}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	if req.Metadata != nil {
+		params.Metadata = req.Metadata
+	}
+
+	stripeConfig, err := s.client.BillingPortalConfigurations.New(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create portal configuration: %w", err)
+	}
+
+	return s.convertStripePortalConfiguration(stripeConfig), nil
+}
+
+// GetPortalConfiguration retrieves a customer portal configuration by ID
+func (s *StripeService) GetPortalConfiguration(ctx context.Context, configurationID string) (*models.PortalConfiguration, error) {
+	params := &stripe.BillingPortalConfigurationParams{}
+	params.Context = ctx
+
+	stripeConfig, err := s.client.BillingPortalConfigurations.Get(configurationID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portal configuration: %w", err)
+	}
+
+	return s.convertStripePortalConfiguration(stripeConfig), nil
+}
+
+// UpdatePortalConfiguration applies a partial update to a customer portal configuration
+func (s *StripeService) UpdatePortalConfiguration(ctx context.Context, configurationID string, req *models.UpdatePortalConfigurationRequest) (*models.PortalConfiguration, error) {
+	params := &stripe.BillingPortalConfigurationParams{}
+	params.Context = ctx
+
+	if req.Active != nil {
+		params.Active = stripe.Bool(*req.Active)
+	}
+
+	if req.Headline != nil || req.PrivacyPolicyURL != nil || req.TermsOfServiceURL != nil {
+		params.BusinessProfile = &stripe.BillingPortalConfigurationBusinessProfileParams{
+			Headline:          req.Headline,
+			PrivacyPolicyURL:  req.PrivacyPolicyURL,
+			TermsOfServiceURL: req.TermsOfServiceURL,
+		}
+	}
+
+	if req.DefaultReturnURL != nil {
+		params.DefaultReturnURL = stripe.String(*req.DefaultReturnURL)
+	}
+
+	if req.Features != nil {
+		params.Features = buildPortalFeaturesParams(req.Features, true)
+	}
+
+	addMetadataUpdates(params, req.Metadata)
+
+	stripeConfig, err := s.client.BillingPortalConfigurations.Update(configurationID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update portal configuration: %w", err)
+	}
+
+	return s.convertStripePortalConfiguration(stripeConfig), nil
+}
+
+// buildPortalFeaturesParams maps the portal feature settings onto Stripe params. With replace set
+// (updates), empty allowed updates and products are sent explicitly so Stripe clears the previous
+// values instead of keeping them.
+func buildPortalFeaturesParams(features *models.PortalFeatures, replace bool) *stripe.BillingPortalConfigurationFeaturesParams {
+	params := &stripe.BillingPortalConfigurationFeaturesParams{
+		InvoiceHistory: &stripe.BillingPortalConfigurationFeaturesInvoiceHistoryParams{
+			Enabled: stripe.Bool(features.InvoiceHistory),
+		},
+		PaymentMethodUpdate: &stripe.BillingPortalConfigurationFeaturesPaymentMethodUpdateParams{
+			Enabled: stripe.Bool(features.PaymentMethodUpdate),
+		},
+		SubscriptionCancel: &stripe.BillingPortalConfigurationFeaturesSubscriptionCancelParams{
+			Enabled:           stripe.Bool(features.SubscriptionCancel.Enabled),
+			Mode:              optionalString(features.SubscriptionCancel.Mode),
+			ProrationBehavior: optionalString(features.SubscriptionCancel.ProrationBehavior),
+		},
+		SubscriptionUpdate: &stripe.BillingPortalConfigurationFeaturesSubscriptionUpdateParams{
+			Enabled:           stripe.Bool(features.SubscriptionUpdate.Enabled),
+			ProrationBehavior: optionalString(features.SubscriptionUpdate.ProrationBehavior),
+		},
+	}
+
+	update := params.SubscriptionUpdate
+	if len(features.SubscriptionUpdate.DefaultAllowedUpdates) > 0 || replace {
+		update.DefaultAllowedUpdates = stripe.StringSlice(features.SubscriptionUpdate.DefaultAllowedUpdates)
+	}
+	if replace {
+		// A non-nil empty slice is encoded as an empty value, which clears the products
+		update.Products = []*stripe.BillingPortalConfigurationFeaturesSubscriptionUpdateProductParams{}
+	}
+	for _, product := range features.SubscriptionUpdate.Products {
+		update.Products = append(update.Products, &stripe.BillingPortalConfigurationFeaturesSubscriptionUpdateProductParams{
+			Product: stripe.String(product.ProductID),
+			Prices:  stripe.StringSlice(product.PriceIDs),
+		})
+	}
+
+	return params
+}
+
+func (s *StripeService) convertStripePortalConfiguration(stripeConfig *stripe.BillingPortalConfiguration) *models.PortalConfiguration {
+	if stripeConfig == nil {
+		return nil
+	}
+
+	config := &models.PortalConfiguration{
+		ID:               stripeConfig.ID,
+		Active:           stripeConfig.Active,
+		IsDefault:        stripeConfig.IsDefault,
+		DefaultReturnURL: stripeConfig.DefaultReturnURL,
+		Metadata:         stripeConfig.Metadata,
+		CreatedAt:        time.Unix(stripeConfig.Created, 0),
+		UpdatedAt:        time.Unix(stripeConfig.Updated, 0),
+	}
+
+	if profile := stripeConfig.BusinessProfile; profile != nil {
+		config.Headline = profile.Headline
+		config.PrivacyPolicyURL = profile.PrivacyPolicyURL
+		config.TermsOfServiceURL = profile.TermsOfServiceURL
+	}
+
+	features := stripeConfig.Features
+	if features == nil {
+		return config
+	}
+
+	if features.InvoiceHistory != nil {
+		config.Features.InvoiceHistory = features.InvoiceHistory.Enabled
+	}
+
+	if features.PaymentMethodUpdate != nil {
+		config.Features.PaymentMethodUpdate = features.PaymentMethodUpdate.Enabled
+	}
+
+	if cancel := features.SubscriptionCancel; cancel != nil {
+		config.Features.SubscriptionCancel = models.PortalSubscriptionCancel{
+			Enabled:           cancel.Enabled,
+			Mode:              string(cancel.Mode),
+			ProrationBehavior: string(cancel.ProrationBehavior),
+		}
+	}
+
+	if update := features.SubscriptionUpdate; update != nil {
+		config.Features.SubscriptionUpdate = models.PortalSubscriptionUpdate{
+			Enabled:           update.Enabled,
+			ProrationBehavior: string(update.ProrationBehavior),
+		}
+		for _, allowed := range update.DefaultAllowedUpdates {
+			config.Features.SubscriptionUpdate.DefaultAllowedUpdates = append(config.Features.SubscriptionUpdate.DefaultAllowedUpdates, string(allowed))
+		}
+		for _, product := range update.Products {
+			config.Features.SubscriptionUpdate.Products = append(config.Features.SubscriptionUpdate.Products, models.PortalProduct{
+				ProductID: product.Product,
+				PriceIDs:  product.Prices,
+			})
+		}
+	}
+
+	return config
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"stripe-service/config"
+	"stripe-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v76"
+	"github.com/stripe/stripe-go/v76/form"
+)
+
+func TestStripeService_Portal(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+	ctx := context.Background()
+
+	// These will fail with the test key, but we're testing the methods exist and handle errors
+	session, err := service.CreatePortalSession(ctx, "cus_test_123", &models.CreatePortalSessionRequest{
+		ReturnURL: "https://example.com/account",
+	})
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, session, "Expected nil result on error")
+
+	portalConfig, err := service.CreatePortalConfiguration(ctx, &models.CreatePortalConfigurationRequest{
+		Features: models.PortalFeatures{InvoiceHistory: true},
+	})
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, portalConfig, "Expected nil result on error")
+
+	portalConfig, err = service.GetPortalConfiguration(ctx, "bpc_test_123")
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, portalConfig, "Expected nil result on error")
+
+	active := false
+	_, err = service.UpdatePortalConfiguration(ctx, "bpc_test_123", &models.UpdatePortalConfigurationRequest{Active: &active})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to update portal configuration")
+}
+
+func TestBuildPortalFeaturesParams(t *testing.T) {
+	params := buildPortalFeaturesParams(&models.PortalFeatures{
+		PaymentMethodUpdate: true,
+		SubscriptionCancel:  models.PortalSubscriptionCancel{Enabled: true, Mode: "at_period_end"},
+		SubscriptionUpdate: models.PortalSubscriptionUpdate{
+			Enabled:               true,
+			DefaultAllowedUpdates: []string{"price"},
+			Products:              []models.PortalProduct{{ProductID: "prod_123", PriceIDs: []string{"price_1", "price_2"}}},
+		},
+	}, false)
+
+	assert.False(t, *params.InvoiceHistory.Enabled)
+	assert.True(t, *params.PaymentMethodUpdate.Enabled)
+	assert.True(t, *params.SubscriptionCancel.Enabled)
+	assert.Equal(t, "at_period_end", *params.SubscriptionCancel.Mode)
+	assert.Nil(t, params.SubscriptionCancel.ProrationBehavior)
+	assert.True(t, *params.SubscriptionUpdate.Enabled)
+	assert.Equal(t, []*string{stripe.String("price")}, params.SubscriptionUpdate.DefaultAllowedUpdates)
+	require.Len(t, params.SubscriptionUpdate.Products, 1)
+	assert.Equal(t, "prod_123", *params.SubscriptionUpdate.Products[0].Product)
+	assert.Len(t, params.SubscriptionUpdate.Products[0].Prices, 2)
+}
+
+func TestBuildPortalFeaturesParams_ReplaceClearsLists(t *testing.T) {
+	features := &models.PortalFeatures{
+		SubscriptionUpdate: models.PortalSubscriptionUpdate{Enabled: false},
+	}
+
+	created := buildPortalFeaturesParams(features, false)
+	assert.Nil(t, created.SubscriptionUpdate.DefaultAllowedUpdates)
+	assert.Nil(t, created.SubscriptionUpdate.Products)
+
+	updated := buildPortalFeaturesParams(features, true)
+	require.NotNil(t, updated.SubscriptionUpdate.DefaultAllowedUpdates)
+	assert.Empty(t, updated.SubscriptionUpdate.DefaultAllowedUpdates)
+	require.NotNil(t, updated.SubscriptionUpdate.Products)
+	assert.Empty(t, updated.SubscriptionUpdate.Products)
+
+	values := &form.Values{}
+	form.AppendTo(values, updated)
+	assert.Equal(t, []string{""}, values.Get("subscription_update[default_allowed_updates]"))
+	assert.Equal(t, []string{""}, values.Get("subscription_update[products]"))
+}
+
+func TestConvertStripePortalConfiguration(t *testing.T) {
+	service := &StripeService{}
+
+	assert.Nil(t, service.convertStripePortalConfiguration(nil))
+
+	result := service.convertStripePortalConfiguration(&stripe.BillingPortalConfiguration{
+		ID:        "bpc_123",
+		Active:    true,
+		IsDefault: true,
+		BusinessProfile: &stripe.BillingPortalConfigurationBusinessProfile{
+			Headline: "Manage your plan",
+		},
+		Features: &stripe.BillingPortalConfigurationFeatures{
+			InvoiceHistory: &stripe.BillingPortalConfigurationFeaturesInvoiceHistory{Enabled: true},
+			SubscriptionCancel: &stripe.BillingPortalConfigurationFeaturesSubscriptionCancel{
+				Enabled: true,
+				Mode:    stripe.BillingPortalConfigurationFeaturesSubscriptionCancelModeAtPeriodEnd,
+			},
+			SubscriptionUpdate: &stripe.BillingPortalConfigurationFeaturesSubscriptionUpdate{
+				Enabled: true,
+				DefaultAllowedUpdates: []stripe.BillingPortalConfigurationFeaturesSubscriptionUpdateDefaultAllowedUpdate{
+					stripe.BillingPortalConfigurationFeaturesSubscriptionUpdateDefaultAllowedUpdatePrice,
+				},
+				Products: []*stripe.BillingPortalConfigurationFeaturesSubscriptionUpdateProduct{
+					{Product: "prod_123", Prices: []string{"price_1"}},
+				},
+			},
+		},
+		Created: 1640995200,
+		Updated: 1641081600,
+	})
+
+	assert.Equal(t, "bpc_123", result.ID)
+	assert.True(t, result.IsDefault)
+	assert.Equal(t, "Manage your plan", result.Headline)
+	assert.True(t, result.Features.InvoiceHistory)
+	assert.False(t, result.Features.PaymentMethodUpdate)
+	assert.Equal(t, "at_period_end", result.Features.SubscriptionCancel.Mode)
+	assert.Equal(t, []string{"price"}, result.Features.SubscriptionUpdate.DefaultAllowedUpdates)
+	assert.Equal(t, []models.PortalProduct{{ProductID: "prod_123", PriceIDs: []string{"price_1"}}}, result.Features.SubscriptionUpdate.Products)
+	assert.Equal(t, time.Unix(1641081600, 0), result.UpdatedAt)
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"stripe-service/internal/models"
+
+	"github.com/stripe/stripe-go/v76"
+)
+
+// Refund operations
+
+// CreateRefund refunds all or part of a payment intent's captured amount
+func (s *StripeService) CreateRefund(ctx context.Context, req *models.CreateRefundRequest) (*models.Refund, error) {
+	piParams := &stripe.PaymentIntentParams{}
+	piParams.Context = ctx
+	piParams.AddExpand("latest_charge")
+
+	stripePI, err := s.client.PaymentIntents.Get(req.PaymentIntentID, piParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment intent: %w", err)
+	}
+
+	if err := validateRefundAmount(stripePI, req.Amount); err != nil {
+		return nil, err
+	}
+
+	params := &stripe.RefundParams{
+		PaymentIntent: stripe.String(req.PaymentIntentID),
+	}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	if req.Amount > 0 {
+		params.Amount = stripe.Int64(req.Amount)
+	}
+
+	if req.Reason != "" {
+		params.Reason = stripe.String(req.Reason)
+	}
+
+	if req.Metadata != nil {
+		params.Metadata = req.Metadata
+	}
+
+	stripeRefund, err := s.client.Refunds.New(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refund: %w", err)
+	}
+
+	return s.convertStripeRefund(stripeRefund), nil
+}
+
+// GetRefund retrieves a refund by ID
+func (s *StripeService) GetRefund(ctx context.Context, refundID string) (*models.Refund, error) {
+	params := &stripe.RefundParams{}
+	params.Context = ctx
+
+	stripeRefund, err := s.client.Refunds.Get(refundID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refund: %w", err)
+	}
+
+	return s.convertStripeRefund(stripeRefund), nil
+}
+
+// ListRefunds lists a single page of refunds, optionally for one payment intent
+func (s *StripeService) ListRefunds(ctx context.Context, req *models.ListRefundsRequest) (*models.ListRefundsResponse, error) {
+	params := &stripe.RefundListParams{}
+	params.Context = ctx
+	params.Single = true
+	params.Limit = stripe.Int64(pageLimit(req.Limit))
+
+	if req.Cursor != "" {
+		params.StartingAfter = stripe.String(req.Cursor)
+	}
+
+	if req.PaymentIntentID != "" {
+		params.PaymentIntent = stripe.String(req.PaymentIntentID)
+	}
+
+	iter := s.client.Refunds.List(params)
+	refunds := []models.Refund{}
+
+	for iter.Next() {
+		refunds = append(refunds, *s.convertStripeRefund(iter.Refund()))
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list refunds: %w", err)
+	}
+
+	response := &models.ListRefundsResponse{
+		Refunds: refunds,
+		HasMore: iter.Meta().HasMore,
+	}
+	if response.HasMore && len(refunds) > 0 {
+		response.NextCursor = refunds[len(refunds)-1].ID
+	}
+
+	return response, nil
+}
+
+// CancelRefund cancels a refund that is still awaiting action
+func (s *StripeService) CancelRefund(ctx context.Context, refundID string) (*models.Refund, error) {
+	params := &stripe.RefundCancelParams{}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	stripeRefund, err := s.client.Refunds.Cancel(refundID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel refund: %w", err)
+	}
+
+	return s.convertStripeRefund(stripeRefund), nil
+}
+
+// validateRefundAmount checks that a refund fits within what has been captured and not yet refunded
+func validateRefundAmount(stripePI *stripe.PaymentIntent, amount int64) error {
+	if stripePI.LatestCharge == nil {
+		return newValidationError("payment_intent_id", "has no captured payment to refund")
+	}
+
+	remaining := stripePI.LatestCharge.AmountCaptured - stripePI.LatestCharge.AmountRefunded
+	if remaining <= 0 {
+		return newValidationError("payment_intent_id", "has no remaining captured amount to refund")
+	}
+
+	if amount > remaining {
+		return newValidationError("amount", "%d exceeds the remaining refundable amount of %d", amount, remaining)
+	}
+
+	return nil
+}
+
+func (s *StripeService) convertStripeRefund(stripeRefund *stripe.Refund) *models.Refund {
+	if stripeRefund == nil {
+		return nil
+	}
+
+	paymentIntentID := ""
+	if stripeRefund.PaymentIntent != nil {
+		paymentIntentID = stripeRefund.PaymentIntent.ID
+	}
+
+	chargeID := ""
+	if stripeRefund.Charge != nil {
+		chargeID = stripeRefund.Charge.ID
+	}
+
+	return &models.Refund{
+		ID:              stripeRefund.ID,
+		Amount:          stripeRefund.Amount,
+		Currency:        string(stripeRefund.Currency),
+		Status:          string(stripeRefund.Status),
+		Reason:          string(stripeRefund.Reason),
+		PaymentIntentID: paymentIntentID,
+		ChargeID:        chargeID,
+		FailureReason:   string(stripeRefund.FailureReason),
+		Metadata:        stripeRefund.Metadata,
+		CreatedAt:       time.Unix(stripeRefund.Created, 0),
+	}
+}
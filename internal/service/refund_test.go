@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"stripe-service/config"
+	"stripe-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v76"
+)
+
+func TestStripeService_Refunds(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+	ctx := context.Background()
+
+	// These will fail with the test key, but we're testing the methods exist and handle errors
+	refund, err := service.CreateRefund(ctx, &models.CreateRefundRequest{PaymentIntentID: "pi_test_123", Amount: 500})
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, refund, "Expected nil result on error")
+
+	refund, err = service.GetRefund(ctx, "re_test_123")
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, refund, "Expected nil result on error")
+
+	refund, err = service.CancelRefund(ctx, "re_test_123")
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, refund, "Expected nil result on error")
+
+	_, err = service.ListRefunds(ctx, &models.ListRefundsRequest{PaymentIntentID: "pi_test_123"})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to list refunds")
+}
+
+func TestValidateRefundAmount(t *testing.T) {
+	partiallyRefunded := &stripe.PaymentIntent{
+		ID: "pi_123",
+		LatestCharge: &stripe.Charge{
+			AmountCaptured: 5000,
+			AmountRefunded: 2000,
+		},
+	}
+
+	assert.NoError(t, validateRefundAmount(partiallyRefunded, 0), "Expected full refund of the remainder to be allowed")
+	assert.NoError(t, validateRefundAmount(partiallyRefunded, 3000))
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, validateRefundAmount(partiallyRefunded, 3001), &validationErr)
+	assert.Equal(t, "amount", validationErr.Field)
+
+	fullyRefunded := &stripe.PaymentIntent{
+		LatestCharge: &stripe.Charge{AmountCaptured: 5000, AmountRefunded: 5000},
+	}
+	require.ErrorAs(t, validateRefundAmount(fullyRefunded, 0), &validationErr)
+	assert.Equal(t, "payment_intent_id", validationErr.Field)
+
+	require.ErrorAs(t, validateRefundAmount(&stripe.PaymentIntent{}, 100), &validationErr)
+}
+
+func TestConvertStripeRefund(t *testing.T) {
+	service := &StripeService{}
+
+	assert.Nil(t, service.convertStripeRefund(nil))
+
+	result := service.convertStripeRefund(&stripe.Refund{
+		ID:            "re_123",
+		Amount:        500,
+		Currency:      stripe.CurrencyUSD,
+		Status:        stripe.RefundStatusSucceeded,
+		Reason:        stripe.RefundReasonRequestedByCustomer,
+		PaymentIntent: &stripe.PaymentIntent{ID: "pi_123"},
+		Charge:        &stripe.Charge{ID: "ch_123"},
+		Metadata:      map[string]string{"ticket": "T-42"},
+		Created:       1640995200,
+	})
+
+	assert.Equal(t, "re_123", result.ID)
+	assert.Equal(t, int64(500), result.Amount)
+	assert.Equal(t, "usd", result.Currency)
+	assert.Equal(t, "succeeded", result.Status)
+	assert.Equal(t, "requested_by_customer", result.Reason)
+	assert.Equal(t, "pi_123", result.PaymentIntentID)
+	assert.Equal(t, "ch_123", result.ChargeID)
+	assert.Equal(t, map[string]string{"ticket": "T-42"}, result.Metadata)
+	assert.Equal(t, time.Unix(1640995200, 0), result.CreatedAt)
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"stripe-service/internal/models"
+
+	"github.com/stripe/stripe-go/v76"
+)
+
+// Setup intent operations
+
+// CreateSetupIntent creates a setup intent for saving a payment method
+func (s *StripeService) CreateSetupIntent(ctx context.Context, req *models.CreateSetupIntentRequest) (*models.SetupIntent, error) {
+	params := &stripe.SetupIntentParams{}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	if req.CustomerID != "" {
+		params.Customer = stripe.String(req.CustomerID)
+	}
+
+	if req.PaymentMethodID != "" {
+		params.PaymentMethod = stripe.String(req.PaymentMethodID)
+	}
+
+	if len(req.PaymentMethodTypes) > 0 {
+		params.PaymentMethodTypes = stripe.StringSlice(req.PaymentMethodTypes)
+	}
+
+	if req.Usage != "" {
+		params.Usage = stripe.String(req.Usage)
+	}
+
+	if req.Description != "" {
+		params.Description = stripe.String(req.Description)
+	}
+
+	if req.Metadata != nil {
+		params.Metadata = req.Metadata
+	}
+
+	stripeSI, err := s.client.SetupIntents.New(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create setup intent: %w", err)
+	}
+
+	return s.convertStripeSetupIntent(stripeSI), nil
+}
+
+// GetSetupIntent retrieves a setup intent by ID
+func (s *StripeService) GetSetupIntent(ctx context.Context, setupIntentID string) (*models.SetupIntent, error) {
+	params := &stripe.SetupIntentParams{}
+	params.Context = ctx
+
+	stripeSI, err := s.client.SetupIntents.Get(setupIntentID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get setup intent: %w", err)
+	}
+
+	return s.convertStripeSetupIntent(stripeSI), nil
+}
+
+// ConfirmSetupIntent confirms a setup intent
+func (s *StripeService) ConfirmSetupIntent(ctx context.Context, setupIntentID string, req *models.ConfirmSetupIntentRequest) (*models.SetupIntent, error) {
+	params := &stripe.SetupIntentConfirmParams{}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	if req.PaymentMethodID != "" {
+		params.PaymentMethod = stripe.String(req.PaymentMethodID)
+	}
+
+	if req.ReturnURL != "" {
+		params.ReturnURL = stripe.String(req.ReturnURL)
+	}
+
+	stripeSI, err := s.client.SetupIntents.Confirm(setupIntentID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm setup intent: %w", err)
+	}
+
+	return s.convertStripeSetupIntent(stripeSI), nil
+}
+
+// CancelSetupIntent cancels a setup intent
+func (s *StripeService) CancelSetupIntent(ctx context.Context, setupIntentID string, req *models.CancelSetupIntentRequest) (*models.SetupIntent, error) {
+	params := &stripe.SetupIntentCancelParams{}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	if req.CancellationReason != "" {
+		params.CancellationReason = stripe.String(req.CancellationReason)
+	}
+
+	stripeSI, err := s.client.SetupIntents.Cancel(setupIntentID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel setup intent: %w", err)
+	}
+
+	return s.convertStripeSetupIntent(stripeSI), nil
+}
+
+func (s *StripeService) convertStripeSetupIntent(stripeSI *stripe.SetupIntent) *models.SetupIntent {
+	if stripeSI == nil {
+		return nil
+	}
+
+	customerID := ""
+	if stripeSI.Customer != nil {
+		customerID = stripeSI.Customer.ID
+	}
+
+	paymentMethodID := ""
+	if stripeSI.PaymentMethod != nil {
+		paymentMethodID = stripeSI.PaymentMethod.ID
+	}
+
+	return &models.SetupIntent{
+		ID:                 stripeSI.ID,
+		Status:             string(stripeSI.Status),
+		Usage:              string(stripeSI.Usage),
+		CustomerID:         customerID,
+		PaymentMethodID:    paymentMethodID,
+		PaymentMethodTypes: stripeSI.PaymentMethodTypes,
+		Description:        stripeSI.Description,
+		Metadata:           stripeSI.Metadata,
+		ClientSecret:       stripeSI.ClientSecret,
+		CancellationReason: string(stripeSI.CancellationReason),
+		CreatedAt:          time.Unix(stripeSI.Created, 0),
+	}
+}
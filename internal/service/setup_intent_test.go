@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"stripe-service/config"
+	"stripe-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v76"
+)
+
+func TestStripeService_SetupIntents(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+	ctx := context.Background()
+
+	// These will fail with the test key, but we're testing the methods exist and handle errors
+	setupIntent, err := service.CreateSetupIntent(ctx, &models.CreateSetupIntentRequest{CustomerID: "cus_test_123", Usage: "off_session"})
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, setupIntent, "Expected nil result on error")
+
+	setupIntent, err = service.GetSetupIntent(ctx, "seti_test_123")
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, setupIntent, "Expected nil result on error")
+
+	setupIntent, err = service.ConfirmSetupIntent(ctx, "seti_test_123", &models.ConfirmSetupIntentRequest{PaymentMethodID: "pm_test_123"})
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, setupIntent, "Expected nil result on error")
+
+	setupIntent, err = service.CancelSetupIntent(ctx, "seti_test_123", &models.CancelSetupIntentRequest{})
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, setupIntent, "Expected nil result on error")
+}
+
+func TestConvertStripeSetupIntent(t *testing.T) {
+	service := &StripeService{}
+
+	assert.Nil(t, service.convertStripeSetupIntent(nil))
+
+	result := service.convertStripeSetupIntent(&stripe.SetupIntent{
+		ID:                 "seti_123",
+		Status:             stripe.SetupIntentStatusSucceeded,
+		Usage:              stripe.SetupIntentUsageOffSession,
+		Customer:           &stripe.Customer{ID: "cus_123"},
+		PaymentMethod:      &stripe.PaymentMethod{ID: "pm_123"},
+		PaymentMethodTypes: []string{"card"},
+		ClientSecret:       "seti_123_secret_456",
+		Created:            1640995200,
+	})
+
+	require.NotNil(t, result)
+	assert.Equal(t, "seti_123", result.ID)
+	assert.Equal(t, "succeeded", result.Status)
+	assert.Equal(t, "off_session", result.Usage)
+	assert.Equal(t, "cus_123", result.CustomerID)
+	assert.Equal(t, "pm_123", result.PaymentMethodID)
+	assert.Equal(t, []string{"card"}, result.PaymentMethodTypes)
+	assert.Equal(t, "seti_123_secret_456", result.ClientSecret)
+	assert.Equal(t, time.Unix(1640995200, 0), result.CreatedAt)
+}
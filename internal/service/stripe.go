@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"stripe-service/config"
@@ -16,6 +18,10 @@ import (
 const (
 	DefaultCustomerLimit = 10
 	MaxCustomerLimit     = 100
+
+	// DefaultListLimit and MaxListLimit bound the page size of the other list endpoints
+	DefaultListLimit = 10
+	MaxListLimit     = 100
 )
 
 // StripeService handles all Stripe operations
@@ -36,224 +42,1782 @@ func NewStripeService(cfg *config.Config) *StripeService {
 	}
 }
 
-// Customer operations
+// Customer operations
+
+// CreateCustomer creates a new customer in Stripe
+func (s *StripeService) CreateCustomer(ctx context.Context, req *models.CreateCustomerRequest) (*models.Customer, error) {
+	params := &stripe.CustomerParams{
+		Email:       stripe.String(req.Email),
+		Name:        stripe.String(req.Name),
+		Description: stripe.String(req.Description),
+	}
+
+	// Set context for cancellation support
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	if req.Phone != "" {
+		params.Phone = stripe.String(req.Phone)
+	}
+
+	if req.Metadata != nil {
+		params.Metadata = req.Metadata
+	}
+
+	stripeCustomer, err := s.client.Customers.New(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create customer: %w", err)
+	}
+
+	return s.convertStripeCustomer(stripeCustomer), nil
+}
+
+// GetCustomer retrieves a customer by ID
+func (s *StripeService) GetCustomer(ctx context.Context, customerID string) (*models.Customer, error) {
+	params := &stripe.CustomerParams{}
+	params.Context = ctx
+
+	stripeCustomer, err := s.client.Customers.Get(customerID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	return s.convertStripeCustomer(stripeCustomer), nil
+}
+
+// ListCustomers lists a single page of customers, using the search API when a
+// search query or metadata filter is given
+func (s *StripeService) ListCustomers(ctx context.Context, req *models.ListCustomersRequest) (*models.ListCustomersResponse, error) {
+	if isCustomerSearch(req) {
+		return s.searchCustomers(ctx, req)
+	}
+
+	params := &stripe.CustomerListParams{}
+	params.Context = ctx
+	// Fetch exactly one page; clients page explicitly with the returned cursors
+	params.Single = true
+	params.Limit = stripe.Int64(customerPageLimit(req.Limit))
+
+	if req.Cursor != "" {
+		params.StartingAfter = stripe.String(req.Cursor)
+	}
+
+	if req.EndingBefore != "" {
+		params.EndingBefore = stripe.String(req.EndingBefore)
+	}
+
+	if req.Email != "" {
+		params.Email = stripe.String(req.Email)
+	}
+
+	if req.CreatedGte > 0 || req.CreatedLte > 0 {
+		params.CreatedRange = &stripe.RangeQueryParams{
+			GreaterThanOrEqual: req.CreatedGte,
+			LesserThanOrEqual:  req.CreatedLte,
+		}
+	}
+
+	iter := s.client.Customers.List(params)
+	customers := []models.Customer{}
+
+	for iter.Next() {
+		customers = append(customers, *s.convertStripeCustomer(iter.Customer()))
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list customers: %w", err)
+	}
+
+	// The iterator reverses pages fetched with ending_before; restore newest-first order
+	if req.EndingBefore != "" {
+		for i, j := 0, len(customers)-1; i < j; i, j = i+1, j-1 {
+			customers[i], customers[j] = customers[j], customers[i]
+		}
+	}
+
+	response := &models.ListCustomersResponse{
+		Customers: customers,
+		HasMore:   iter.Meta().HasMore,
+	}
+	response.NextCursor, response.PrevCursor = customerPageCursors(customers, response.HasMore, req)
+
+	return response, nil
+}
+
+// searchCustomers runs a single page of a customer search query
+func (s *StripeService) searchCustomers(ctx context.Context, req *models.ListCustomersRequest) (*models.ListCustomersResponse, error) {
+	params := &stripe.CustomerSearchParams{}
+	params.Context = ctx
+	params.Single = true
+	params.Query = buildCustomerSearchQuery(req)
+	params.Limit = stripe.Int64(customerPageLimit(req.Limit))
+
+	if req.Page != "" {
+		params.Page = stripe.String(req.Page)
+	}
+
+	iter := s.client.Customers.Search(params)
+	customers := []models.Customer{}
+
+	for iter.Next() {
+		customers = append(customers, *s.convertStripeCustomer(iter.Customer()))
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search customers: %w", err)
+	}
+
+	response := &models.ListCustomersResponse{
+		Customers: customers,
+		HasMore:   iter.Meta().HasMore,
+	}
+	if iter.Meta().NextPage != nil {
+		response.NextPage = *iter.Meta().NextPage
+	}
+
+	return response, nil
+}
+
+// isCustomerSearch reports whether the request needs the search API
+func isCustomerSearch(req *models.ListCustomersRequest) bool {
+	return req.Query != "" || req.Page != "" || len(req.Metadata) > 0
+}
+
+// buildCustomerSearchQuery ANDs the request filters onto the caller's search query
+func buildCustomerSearchQuery(req *models.ListCustomersRequest) string {
+	var clauses []string
+
+	if req.Query != "" {
+		clauses = append(clauses, req.Query)
+	}
+
+	if req.Email != "" {
+		clauses = append(clauses, fmt.Sprintf("email:%s", quoteSearchValue(req.Email)))
+	}
+
+	if req.CreatedGte > 0 {
+		clauses = append(clauses, fmt.Sprintf("created>=%d", req.CreatedGte))
+	}
+
+	if req.CreatedLte > 0 {
+		clauses = append(clauses, fmt.Sprintf("created<=%d", req.CreatedLte))
+	}
+
+	// Sort metadata keys so the generated query is deterministic
+	keys := make([]string, 0, len(req.Metadata))
+	for key := range req.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		clauses = append(clauses, fmt.Sprintf("metadata[%s]:%s", quoteSearchValue(key), quoteSearchValue(req.Metadata[key])))
+	}
+
+	return strings.Join(clauses, " AND ")
+}
+
+// quoteSearchValue wraps a value in single quotes for Stripe's search query language
+func quoteSearchValue(value string) string {
+	escaped := strings.ReplaceAll(value, `\`, `\\`)
+	escaped = strings.ReplaceAll(escaped, `'`, `\'`)
+	return "'" + escaped + "'"
+}
+
+// customerPageLimit applies the default and maximum page size
+func customerPageLimit(limit int64) int64 {
+	switch {
+	case limit <= 0:
+		return DefaultCustomerLimit
+	case limit > MaxCustomerLimit:
+		return MaxCustomerLimit
+	default:
+		return limit
+	}
+}
+
+// pageLimit applies the default and maximum page size for list endpoints
+func pageLimit(limit int64) int64 {
+	switch {
+	case limit <= 0:
+		return DefaultListLimit
+	case limit > MaxListLimit:
+		return MaxListLimit
+	default:
+		return limit
+	}
+}
+
+// customerPageCursors derives the cursors for the pages adjacent to a newest-first page.
+// When paging backward, hasMore refers to newer customers rather than older ones.
+func customerPageCursors(customers []models.Customer, hasMore bool, req *models.ListCustomersRequest) (next, prev string) {
+	if len(customers) == 0 {
+		return "", ""
+	}
+	first, last := customers[0].ID, customers[len(customers)-1].ID
+
+	if req.EndingBefore != "" {
+		next = last
+		if hasMore {
+			prev = first
+		}
+		return next, prev
+	}
+
+	if hasMore {
+		next = last
+	}
+	if req.Cursor != "" {
+		prev = first
+	}
+	return next, prev
+}
+
+// UpdateCustomer applies a partial update to a customer
+func (s *StripeService) UpdateCustomer(ctx context.Context, customerID string, req *models.UpdateCustomerRequest) (*models.Customer, error) {
+	params := &stripe.CustomerParams{}
+	params.Context = ctx
+
+	if req.Email != nil {
+		params.Email = stripe.String(*req.Email)
+	}
+
+	if req.Name != nil {
+		params.Name = stripe.String(*req.Name)
+	}
+
+	if req.Phone != nil {
+		params.Phone = stripe.String(*req.Phone)
+	}
+
+	if req.Description != nil {
+		params.Description = stripe.String(*req.Description)
+	}
+
+	addMetadataUpdates(params, req.Metadata)
+
+	stripeCustomer, err := s.client.Customers.Update(customerID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update customer: %w", err)
+	}
+
+	return s.convertStripeCustomer(stripeCustomer), nil
+}
+
+// DeleteCustomer permanently deletes a customer
+func (s *StripeService) DeleteCustomer(ctx context.Context, customerID string) (*models.DeletedCustomer, error) {
+	params := &stripe.CustomerParams{}
+	params.Context = ctx
+
+	stripeCustomer, err := s.client.Customers.Del(customerID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete customer: %w", err)
+	}
+
+	return &models.DeletedCustomer{
+		ID:      stripeCustomer.ID,
+		Deleted: stripeCustomer.Deleted,
+	}, nil
+}
+
+// Payment operations
+
+// CreatePaymentIntent creates a new payment intent
+func (s *StripeService) CreatePaymentIntent(ctx context.Context, req *models.CreatePaymentIntentRequest) (*models.PaymentIntent, error) {
+	if err := validateCurrency("currency", req.Currency); err != nil {
+		return nil, err
+	}
+
+	amount := req.Amount
+	if req.PriceID != "" {
+		stripePrice, err := s.getPriceWithCurrencyOptions(ctx, req.PriceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create payment intent: %w", err)
+		}
+
+		amount, err = paymentAmountForPrice(stripePrice, req.Currency, req.Quantity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	params := &stripe.PaymentIntentParams{
+		Amount:   stripe.Int64(amount),
+		Currency: stripe.String(req.Currency),
+	}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	if req.CustomerID != "" {
+		params.Customer = stripe.String(req.CustomerID)
+	}
+
+	if req.Description != "" {
+		params.Description = stripe.String(req.Description)
+	}
+
+	if req.Metadata != nil {
+		params.Metadata = req.Metadata
+	}
+
+	if req.PaymentMethodID != "" {
+		params.PaymentMethod = stripe.String(req.PaymentMethodID)
+	}
+
+	if req.ConfirmationMethod != "" {
+		params.ConfirmationMethod = stripe.String(req.ConfirmationMethod)
+	}
+
+	if req.CaptureMethod != "" {
+		params.CaptureMethod = stripe.String(req.CaptureMethod)
+	}
+
+	stripePI, err := s.client.PaymentIntents.New(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment intent: %w", err)
+	}
+
+	return s.convertStripePaymentIntent(stripePI), nil
+}
+
+// ConfirmPaymentIntent confirms a payment intent
+func (s *StripeService) ConfirmPaymentIntent(ctx context.Context, paymentIntentID string, req *models.ConfirmPaymentIntentRequest) (*models.PaymentIntent, error) {
+	params := &stripe.PaymentIntentConfirmParams{}
+	params.Context = ctx
+	params.AddExpand("latest_charge")
+	setIdempotencyKey(ctx, &params.Params)
+
+	if req.PaymentMethodID != "" {
+		params.PaymentMethod = stripe.String(req.PaymentMethodID)
+	}
+
+	if req.ReturnURL != "" {
+		params.ReturnURL = stripe.String(req.ReturnURL)
+	}
+
+	stripePI, err := s.client.PaymentIntents.Confirm(paymentIntentID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm payment intent: %w", err)
+	}
+
+	return s.convertStripePaymentIntent(stripePI), nil
+}
+
+// GetPaymentIntent retrieves a payment intent by ID
+func (s *StripeService) GetPaymentIntent(ctx context.Context, paymentIntentID string) (*models.PaymentIntent, error) {
+	params := &stripe.PaymentIntentParams{}
+	params.Context = ctx
+	params.AddExpand("latest_charge")
+
+	stripePI, err := s.client.PaymentIntents.Get(paymentIntentID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment intent: %w", err)
+	}
+
+	return s.convertStripePaymentIntent(stripePI), nil
+}
+
+// UpdatePaymentIntent applies a partial update to a payment intent
+func (s *StripeService) UpdatePaymentIntent(ctx context.Context, paymentIntentID string, req *models.UpdatePaymentIntentRequest) (*models.PaymentIntent, error) {
+	params := &stripe.PaymentIntentParams{}
+	params.Context = ctx
+
+	if req.Amount != nil {
+		params.Amount = stripe.Int64(*req.Amount)
+	}
+
+	if req.Currency != nil {
+		if err := validateCurrency("currency", *req.Currency); err != nil {
+			return nil, err
+		}
+		params.Currency = stripe.String(*req.Currency)
+	}
+
+	if req.CustomerID != nil {
+		params.Customer = stripe.String(*req.CustomerID)
+	}
+
+	if req.Description != nil {
+		params.Description = stripe.String(*req.Description)
+	}
+
+	if req.PaymentMethodID != nil {
+		params.PaymentMethod = stripe.String(*req.PaymentMethodID)
+	}
+
+	addMetadataUpdates(params, req.Metadata)
+
+	stripePI, err := s.client.PaymentIntents.Update(paymentIntentID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update payment intent: %w", err)
+	}
+
+	return s.convertStripePaymentIntent(stripePI), nil
+}
+
+// CancelPaymentIntent cancels a payment intent
+func (s *StripeService) CancelPaymentIntent(ctx context.Context, paymentIntentID string, req *models.CancelPaymentIntentRequest) (*models.PaymentIntent, error) {
+	params := &stripe.PaymentIntentCancelParams{}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	if req.CancellationReason != "" {
+		params.CancellationReason = stripe.String(req.CancellationReason)
+	}
+
+	stripePI, err := s.client.PaymentIntents.Cancel(paymentIntentID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel payment intent: %w", err)
+	}
+
+	return s.convertStripePaymentIntent(stripePI), nil
+}
+
+// CapturePaymentIntent captures funds held by an uncaptured payment intent. Partial captures
+// are checked against the authorized amount before they are sent to Stripe.
+func (s *StripeService) CapturePaymentIntent(ctx context.Context, paymentIntentID string, req *models.CapturePaymentIntentRequest) (*models.PaymentIntent, error) {
+	params := &stripe.PaymentIntentCaptureParams{}
+	params.Context = ctx
+	params.AddExpand("latest_charge")
+	setIdempotencyKey(ctx, &params.Params)
+
+	if req.AmountToCapture > 0 {
+		current, err := s.GetPaymentIntent(ctx, paymentIntentID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := validateCaptureAmount(current, req.AmountToCapture); err != nil {
+			return nil, err
+		}
+
+		params.AmountToCapture = stripe.Int64(req.AmountToCapture)
+	}
+
+	stripePI, err := s.client.PaymentIntents.Capture(paymentIntentID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture payment intent: %w", err)
+	}
+
+	return s.convertStripePaymentIntent(stripePI), nil
+}
+
+// validateCaptureAmount checks that a partial capture fits within the authorized hold
+func validateCaptureAmount(paymentIntent *models.PaymentIntent, amountToCapture int64) error {
+	if paymentIntent.Status != string(stripe.PaymentIntentStatusRequiresCapture) {
+		return newValidationError("payment_intent", "has status %s and cannot be captured", paymentIntent.Status)
+	}
+
+	if amountToCapture > paymentIntent.AmountCapturable {
+		return newValidationError("amount_to_capture", "%d exceeds the authorized amount of %d", amountToCapture, paymentIntent.AmountCapturable)
+	}
+
+	return nil
+}
+
+// ListPaymentIntents lists a single page of payment intents, using the search API
+// when filtering by status
+func (s *StripeService) ListPaymentIntents(ctx context.Context, req *models.ListPaymentIntentsRequest) (*models.ListPaymentIntentsResponse, error) {
+	if req.Status != "" || req.Page != "" {
+		return s.searchPaymentIntents(ctx, req)
+	}
+
+	params := &stripe.PaymentIntentListParams{}
+	params.Context = ctx
+	params.Single = true
+	params.Limit = stripe.Int64(pageLimit(req.Limit))
+
+	if req.Cursor != "" {
+		params.StartingAfter = stripe.String(req.Cursor)
+	}
+
+	if req.CustomerID != "" {
+		params.Customer = stripe.String(req.CustomerID)
+	}
+
+	if req.CreatedGte > 0 || req.CreatedLte > 0 {
+		params.CreatedRange = &stripe.RangeQueryParams{
+			GreaterThanOrEqual: req.CreatedGte,
+			LesserThanOrEqual:  req.CreatedLte,
+		}
+	}
+
+	iter := s.client.PaymentIntents.List(params)
+	paymentIntents := []models.PaymentIntent{}
+
+	for iter.Next() {
+		paymentIntents = append(paymentIntents, *s.convertStripePaymentIntent(iter.PaymentIntent()))
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list payment intents: %w", err)
+	}
+
+	response := &models.ListPaymentIntentsResponse{
+		PaymentIntents: paymentIntents,
+		HasMore:        iter.Meta().HasMore,
+	}
+	if response.HasMore && len(paymentIntents) > 0 {
+		response.NextCursor = paymentIntents[len(paymentIntents)-1].ID
+	}
+
+	return response, nil
+}
+
+// searchPaymentIntents runs a single page of a payment intent search
+func (s *StripeService) searchPaymentIntents(ctx context.Context, req *models.ListPaymentIntentsRequest) (*models.ListPaymentIntentsResponse, error) {
+	params := &stripe.PaymentIntentSearchParams{}
+	params.Context = ctx
+	params.Single = true
+	params.Query = buildPaymentIntentSearchQuery(req)
+	params.Limit = stripe.Int64(pageLimit(req.Limit))
+
+	if req.Page != "" {
+		params.Page = stripe.String(req.Page)
+	}
+
+	iter := s.client.PaymentIntents.Search(params)
+	paymentIntents := []models.PaymentIntent{}
+
+	for iter.Next() {
+		paymentIntents = append(paymentIntents, *s.convertStripePaymentIntent(iter.PaymentIntent()))
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search payment intents: %w", err)
+	}
+
+	response := &models.ListPaymentIntentsResponse{
+		PaymentIntents: paymentIntents,
+		HasMore:        iter.Meta().HasMore,
+	}
+	if iter.Meta().NextPage != nil {
+		response.NextPage = *iter.Meta().NextPage
+	}
+
+	return response, nil
+}
+
+// buildPaymentIntentSearchQuery translates the list filters into Stripe search syntax
+func buildPaymentIntentSearchQuery(req *models.ListPaymentIntentsRequest) string {
+	var clauses []string
+
+	if req.Status != "" {
+		clauses = append(clauses, fmt.Sprintf("status:%s", quoteSearchValue(req.Status)))
+	}
+
+	if req.CustomerID != "" {
+		clauses = append(clauses, fmt.Sprintf("customer:%s", quoteSearchValue(req.CustomerID)))
+	}
+
+	if req.CreatedGte > 0 {
+		clauses = append(clauses, fmt.Sprintf("created>=%d", req.CreatedGte))
+	}
+
+	if req.CreatedLte > 0 {
+		clauses = append(clauses, fmt.Sprintf("created<=%d", req.CreatedLte))
+	}
+
+	return strings.Join(clauses, " AND ")
+}
+
+// Product operations
+
+// CreateProduct creates a new product
+func (s *StripeService) CreateProduct(ctx context.Context, req *models.CreateProductRequest) (*models.Product, error) {
+	params := &stripe.ProductParams{
+		Name:        stripe.String(req.Name),
+		Description: stripe.String(req.Description),
+		Active:      stripe.Bool(req.Active),
+	}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	if req.Metadata != nil {
+		params.Metadata = req.Metadata
+	}
+
+	stripeProduct, err := s.client.Products.New(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create product: %w", err)
+	}
+
+	return s.convertStripeProduct(stripeProduct), nil
+}
+
+// GetProduct retrieves a product by ID
+func (s *StripeService) GetProduct(ctx context.Context, productID string) (*models.Product, error) {
+	params := &stripe.ProductParams{}
+	params.Context = ctx
+
+	stripeProduct, err := s.client.Products.Get(productID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	return s.convertStripeProduct(stripeProduct), nil
+}
+
+// ListProducts lists a single page of products, optionally filtered by active state
+func (s *StripeService) ListProducts(ctx context.Context, req *models.ListProductsRequest) (*models.ListProductsResponse, error) {
+	params := &stripe.ProductListParams{}
+	params.Context = ctx
+	params.Single = true
+	params.Limit = stripe.Int64(pageLimit(req.Limit))
+
+	if req.Cursor != "" {
+		params.StartingAfter = stripe.String(req.Cursor)
+	}
+
+	if req.Active != nil {
+		params.Active = stripe.Bool(*req.Active)
+	}
+
+	iter := s.client.Products.List(params)
+	products := []models.Product{}
+
+	for iter.Next() {
+		products = append(products, *s.convertStripeProduct(iter.Product()))
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+
+	response := &models.ListProductsResponse{
+		Products: products,
+		HasMore:  iter.Meta().HasMore,
+	}
+
+	if response.HasMore && len(products) > 0 {
+		response.NextCursor = products[len(products)-1].ID
+	}
+
+	return response, nil
+}
+
+// UpdateProduct applies a partial update to a product
+func (s *StripeService) UpdateProduct(ctx context.Context, productID string, req *models.UpdateProductRequest) (*models.Product, error) {
+	params := &stripe.ProductParams{}
+	params.Context = ctx
+
+	if req.Name != nil {
+		params.Name = stripe.String(*req.Name)
+	}
+
+	if req.Description != nil {
+		params.Description = stripe.String(*req.Description)
+	}
+
+	// A non-nil empty slice is sent as images="" which removes all images
+	if req.Images != nil {
+		params.Images = stripe.StringSlice(*req.Images)
+	}
+
+	if req.DefaultPriceID != nil {
+		params.DefaultPrice = stripe.String(*req.DefaultPriceID)
+	}
+
+	if req.Active != nil {
+		params.Active = stripe.Bool(*req.Active)
+	}
+
+	addMetadataUpdates(params, req.Metadata)
+
+	stripeProduct, err := s.client.Products.Update(productID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+
+	return s.convertStripeProduct(stripeProduct), nil
+}
+
+// ArchiveProduct deactivates a product so it can no longer be used for new purchases
+// while existing prices, subscriptions and invoices keep referring to it
+func (s *StripeService) ArchiveProduct(ctx context.Context, productID string) (*models.Product, error) {
+	params := &stripe.ProductParams{
+		Active: stripe.Bool(false),
+	}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	stripeProduct, err := s.client.Products.Update(productID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive product: %w", err)
+	}
+
+	return s.convertStripeProduct(stripeProduct), nil
+}
+
+// DeleteProduct permanently deletes a product. Stripe only allows deleting products
+// without prices, so products that have any are rejected and should be archived instead.
+func (s *StripeService) DeleteProduct(ctx context.Context, productID string) (*models.DeletedProduct, error) {
+	priceParams := &stripe.PriceListParams{
+		Product: stripe.String(productID),
+	}
+	priceParams.Context = ctx
+	priceParams.Single = true
+	priceParams.Limit = stripe.Int64(1)
+
+	prices := s.client.Prices.List(priceParams)
+	hasPrices := prices.Next()
+	if err := prices.Err(); err != nil {
+		return nil, fmt.Errorf("failed to delete product: %w", err)
+	}
+
+	if hasPrices {
+		return nil, newValidationError("product", "%s has prices and cannot be deleted; archive it instead", productID)
+	}
+
+	params := &stripe.ProductParams{}
+	params.Context = ctx
+
+	stripeProduct, err := s.client.Products.Del(productID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete product: %w", err)
+	}
+
+	return &models.DeletedProduct{
+		ID:      stripeProduct.ID,
+		Deleted: stripeProduct.Deleted,
+	}, nil
+}
+
+// CreatePrice creates a new price
+func (s *StripeService) CreatePrice(ctx context.Context, req *models.CreatePriceRequest) (*models.Price, error) {
+	if err := validateRecurringPrice(req); err != nil {
+		return nil, err
+	}
+
+	if err := validateTieredPrice(req); err != nil {
+		return nil, err
+	}
+
+	if err := validatePriceCurrencies(req); err != nil {
+		return nil, err
+	}
+
+	params := &stripe.PriceParams{
+		Product:  stripe.String(req.ProductID),
+		Currency: stripe.String(req.Currency),
+		Active:   stripe.Bool(req.Active),
+	}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+	// Tiers and currency options are only returned when expanded
+	params.AddExpand("tiers")
+	params.AddExpand("currency_options")
+
+	for currency, option := range req.CurrencyOptions {
+		if params.CurrencyOptions == nil {
+			params.CurrencyOptions = make(map[string]*stripe.PriceCurrencyOptionsParams)
+		}
+		params.CurrencyOptions[strings.ToLower(currency)] = &stripe.PriceCurrencyOptionsParams{
+			UnitAmount: stripe.Int64(option.UnitAmount),
+		}
+	}
+
+	if req.Type == "recurring" {
+		params.Recurring = buildPriceRecurringParams(req)
+	}
+
+	if req.BillingScheme == "tiered" {
+		params.BillingScheme = stripe.String(req.BillingScheme)
+		params.TiersMode = stripe.String(req.TiersMode)
+		params.Tiers = buildPriceTierParams(req.Tiers)
+	} else {
+		params.UnitAmount = stripe.Int64(req.UnitAmount)
+	}
+
+	if req.TransformQuantity != nil {
+		params.TransformQuantity = &stripe.PriceTransformQuantityParams{
+			DivideBy: stripe.Int64(req.TransformQuantity.DivideBy),
+			Round:    stripe.String(req.TransformQuantity.Round),
+		}
+	}
+
+	if req.Nickname != "" {
+		params.Nickname = stripe.String(req.Nickname)
+	}
+
+	if req.LookupKey != "" {
+		params.LookupKey = stripe.String(req.LookupKey)
+		params.TransferLookupKey = stripe.Bool(req.TransferLookupKey)
+	}
+
+	if req.Metadata != nil {
+		params.Metadata = req.Metadata
+	}
+
+	stripePrice, err := s.client.Prices.New(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create price: %w", err)
+	}
+
+	return s.convertStripePrice(stripePrice), nil
+}
+
+// maxIntervalCounts caps interval_count so a billing period is at most three years
+var maxIntervalCounts = map[string]int64{
+	"day":   1095,
+	"week":  156,
+	"month": 36,
+	"year":  3,
+}
+
+// validateRecurringPrice checks the recurring options that depend on the price type
+// and on each other
+func validateRecurringPrice(req *models.CreatePriceRequest) error {
+	if req.Type != "recurring" {
+		if req.RecurringInterval != "" || req.IntervalCount > 0 || req.UsageType != "" || req.AggregateUsage != "" || req.TrialPeriodDays > 0 {
+			return newValidationError("type", "must be recurring to set recurring options")
+		}
+		return nil
+	}
+
+	maxCount, ok := maxIntervalCounts[req.RecurringInterval]
+	if !ok {
+		return newValidationError("recurring_interval", "must be one of day, week, month or year for recurring prices")
+	}
+
+	if req.IntervalCount > maxCount {
+		return newValidationError("interval_count", "must be at most %d for %s intervals", maxCount, req.RecurringInterval)
+	}
+
+	if req.AggregateUsage != "" && req.UsageType != "metered" {
+		return newValidationError("aggregate_usage", "requires usage_type metered")
+	}
+
+	return nil
+}
+
+// validatePriceCurrencies checks the price currency and the additional currencies
+// of its currency options against ISO 4217
+func validatePriceCurrencies(req *models.CreatePriceRequest) error {
+	if err := validateCurrency("currency", req.Currency); err != nil {
+		return err
+	}
+
+	if len(req.CurrencyOptions) > 0 && req.BillingScheme == "tiered" {
+		return newValidationError("currency_options", "are only supported for per-unit prices")
+	}
+
+	for currency := range req.CurrencyOptions {
+		if err := validateCurrency("currency_options", currency); err != nil {
+			return err
+		}
+
+		if strings.EqualFold(currency, req.Currency) {
+			return newValidationError("currency_options", "must not repeat the price currency %s", req.Currency)
+		}
+	}
+
+	return nil
+}
+
+// getPriceWithCurrencyOptions retrieves a price together with its per-currency amounts
+func (s *StripeService) getPriceWithCurrencyOptions(ctx context.Context, priceID string) (*stripe.Price, error) {
+	params := &stripe.PriceParams{}
+	params.Context = ctx
+	params.AddExpand("currency_options")
+
+	stripePrice, err := s.client.Prices.Get(priceID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price: %w", err)
+	}
+
+	return stripePrice, nil
+}
+
+// unitAmountForCurrency returns the price's unit amount in the given currency, taken
+// from its currency options when the currency is not the price's default
+func unitAmountForCurrency(stripePrice *stripe.Price, currency string) (int64, error) {
+	currency = strings.ToLower(currency)
+
+	if string(stripePrice.Currency) == currency {
+		return stripePrice.UnitAmount, nil
+	}
+
+	if option, ok := stripePrice.CurrencyOptions[currency]; ok && option != nil {
+		return option.UnitAmount, nil
+	}
+
+	return 0, newValidationError("currency", "%s is not available for price %s", currency, stripePrice.ID)
+}
+
+// paymentAmountForPrice computes the amount to charge for quantity units of a
+// one-time, per-unit price in the given currency
+func paymentAmountForPrice(stripePrice *stripe.Price, currency string, quantity int64) (int64, error) {
+	if stripePrice.Type == stripe.PriceTypeRecurring {
+		return 0, newValidationError("price_id", "must be a one-time price; use a subscription for recurring prices")
+	}
+
+	if stripePrice.BillingScheme == stripe.PriceBillingSchemeTiered {
+		return 0, newValidationError("price_id", "must be a per-unit price")
+	}
+
+	unitAmount, err := unitAmountForCurrency(stripePrice, currency)
+	if err != nil {
+		return 0, err
+	}
+
+	if quantity == 0 {
+		quantity = 1
+	}
+
+	return unitAmount * quantity, nil
+}
+
+// validateTieredPrice checks that tiered prices carry a tiers mode and an ordered list
+// of tiers ending in an unbounded "inf" tier, and that per-unit prices carry none
+func validateTieredPrice(req *models.CreatePriceRequest) error {
+	if req.BillingScheme != "tiered" {
+		if req.TiersMode != "" || len(req.Tiers) > 0 {
+			return newValidationError("billing_scheme", "must be tiered to set tiers or tiers_mode")
+		}
+		return nil
+	}
+
+	if req.Type != "recurring" {
+		return newValidationError("billing_scheme", "tiered is only supported for recurring prices")
+	}
+
+	if req.UnitAmount != 0 {
+		return newValidationError("unit_amount", "must not be set for tiered prices; set amounts on the tiers instead")
+	}
+
+	if req.TiersMode == "" {
+		return newValidationError("tiers_mode", "is required for tiered prices")
+	}
+
+	if req.TransformQuantity != nil {
+		return newValidationError("transform_quantity", "cannot be combined with tiers")
+	}
+
+	if len(req.Tiers) == 0 {
+		return newValidationError("tiers", "must contain at least one tier for tiered prices")
+	}
+
+	var previous models.TierUpTo
+	last := len(req.Tiers) - 1
+
+	for i, tier := range req.Tiers {
+		if tier.UnitAmount == nil && tier.FlatAmount == nil {
+			return newValidationError("tiers", "tier %d needs a unit_amount or flat_amount", i+1)
+		}
+
+		if i == last {
+			if tier.UpTo != models.TierUpToInf {
+				return newValidationError("tiers", "last tier must have up_to inf")
+			}
+			break
+		}
+
+		if tier.UpTo == models.TierUpToInf {
+			return newValidationError("tiers", "only the last tier may have up_to inf")
+		}
+
+		if tier.UpTo <= previous {
+			return newValidationError("tiers", "up_to must increase with each tier; tier %d has %d after %d", i+1, tier.UpTo, previous)
+		}
+		previous = tier.UpTo
+	}
+
+	return nil
+}
+
+// buildPriceTierParams maps price tiers onto Stripe params
+func buildPriceTierParams(tiers []models.PriceTier) []*stripe.PriceTierParams {
+	params := make([]*stripe.PriceTierParams, 0, len(tiers))
+
+	for _, tier := range tiers {
+		tierParams := &stripe.PriceTierParams{
+			UnitAmount: tier.UnitAmount,
+			FlatAmount: tier.FlatAmount,
+		}
+
+		if tier.UpTo == models.TierUpToInf {
+			tierParams.UpToInf = stripe.Bool(true)
+		} else {
+			tierParams.UpTo = stripe.Int64(int64(tier.UpTo))
+		}
+
+		params = append(params, tierParams)
+	}
+
+	return params
+}
+
+// buildPriceRecurringParams maps the recurring options of a price request onto Stripe params
+func buildPriceRecurringParams(req *models.CreatePriceRequest) *stripe.PriceRecurringParams {
+	params := &stripe.PriceRecurringParams{
+		Interval: stripe.String(req.RecurringInterval),
+	}
+
+	if req.IntervalCount > 0 {
+		params.IntervalCount = stripe.Int64(req.IntervalCount)
+	}
+
+	if req.UsageType != "" {
+		params.UsageType = stripe.String(req.UsageType)
+	}
+
+	if req.AggregateUsage != "" {
+		params.AggregateUsage = stripe.String(req.AggregateUsage)
+	}
+
+	if req.TrialPeriodDays > 0 {
+		params.TrialPeriodDays = stripe.Int64(req.TrialPeriodDays)
+	}
+
+	return params
+}
+
+// GetPrice retrieves a price by ID
+func (s *StripeService) GetPrice(ctx context.Context, priceID string) (*models.Price, error) {
+	params := &stripe.PriceParams{}
+	params.Context = ctx
+	params.AddExpand("tiers")
+	params.AddExpand("currency_options")
+
+	stripePrice, err := s.client.Prices.Get(priceID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price: %w", err)
+	}
+
+	return s.convertStripePrice(stripePrice), nil
+}
+
+// ListPrices lists a single page of prices filtered by product, state, type,
+// currency or lookup keys
+func (s *StripeService) ListPrices(ctx context.Context, req *models.ListPricesRequest) (*models.ListPricesResponse, error) {
+	params := &stripe.PriceListParams{}
+	params.Context = ctx
+	params.Single = true
+	params.Limit = stripe.Int64(pageLimit(req.Limit))
+	params.AddExpand("data.tiers")
+	params.AddExpand("data.currency_options")
+
+	if req.Cursor != "" {
+		params.StartingAfter = stripe.String(req.Cursor)
+	}
+
+	if req.ProductID != "" {
+		params.Product = stripe.String(req.ProductID)
+	}
+
+	if req.Active != nil {
+		params.Active = stripe.Bool(*req.Active)
+	}
+
+	if req.Type != "" {
+		params.Type = stripe.String(req.Type)
+	}
+
+	if req.Currency != "" {
+		params.Currency = stripe.String(strings.ToLower(req.Currency))
+	}
+
+	if len(req.LookupKeys) > 0 {
+		params.LookupKeys = stripe.StringSlice(req.LookupKeys)
+	}
+
+	iter := s.client.Prices.List(params)
+	prices := []models.Price{}
+
+	for iter.Next() {
+		prices = append(prices, *s.convertStripePrice(iter.Price()))
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list prices: %w", err)
+	}
+
+	response := &models.ListPricesResponse{
+		Prices:  prices,
+		HasMore: iter.Meta().HasMore,
+	}
+
+	if response.HasMore && len(prices) > 0 {
+		response.NextCursor = prices[len(prices)-1].ID
+	}
+
+	return response, nil
+}
+
+// UpdatePrice applies a partial update to a price
+func (s *StripeService) UpdatePrice(ctx context.Context, priceID string, req *models.UpdatePriceRequest) (*models.Price, error) {
+	params := &stripe.PriceParams{}
+	params.Context = ctx
+	params.AddExpand("tiers")
+	params.AddExpand("currency_options")
+
+	if req.Active != nil {
+		params.Active = stripe.Bool(*req.Active)
+	}
+
+	if req.Nickname != nil {
+		params.Nickname = stripe.String(*req.Nickname)
+	}
+
+	if req.LookupKey != nil {
+		params.LookupKey = stripe.String(*req.LookupKey)
+		params.TransferLookupKey = stripe.Bool(req.TransferLookupKey)
+	}
+
+	addMetadataUpdates(params, req.Metadata)
+
+	stripePrice, err := s.client.Prices.Update(priceID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update price: %w", err)
+	}
+
+	return s.convertStripePrice(stripePrice), nil
+}
+
+// Subscription operations
+
+// CreateSubscription creates a new subscription
+func (s *StripeService) CreateSubscription(ctx context.Context, req *models.CreateSubscriptionRequest) (*models.Subscription, error) {
+	if err := validateSubscriptionItems(req); err != nil {
+		return nil, err
+	}
+
+	if err := validateSubscriptionTrial(req); err != nil {
+		return nil, err
+	}
+
+	params := &stripe.SubscriptionParams{
+		Customer: stripe.String(req.CustomerID),
+		Items:    buildSubscriptionItemsParams(req),
+	}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	if req.TrialPeriodDays > 0 {
+		params.TrialPeriodDays = stripe.Int64(req.TrialPeriodDays)
+	}
+
+	if req.TrialEnd > 0 {
+		params.TrialEnd = stripe.Int64(req.TrialEnd)
+	}
+
+	if req.TrialSettings != nil {
+		params.TrialSettings = &stripe.SubscriptionTrialSettingsParams{
+			EndBehavior: &stripe.SubscriptionTrialSettingsEndBehaviorParams{
+				MissingPaymentMethod: stripe.String(req.TrialSettings.EndBehavior.MissingPaymentMethod),
+			},
+		}
+	}
+
+	if req.PaymentBehavior != "" {
+		params.PaymentBehavior = stripe.String(req.PaymentBehavior)
+	}
+
+	if req.Metadata != nil {
+		params.Metadata = req.Metadata
+	}
+
+	stripeSub, err := s.client.Subscriptions.New(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create subscription: %w", err)
+	}
+
+	return s.convertStripeSubscription(stripeSub), nil
+}
+
+// validateSubscriptionItems rejects a price that appears on more than one item
+func validateSubscriptionItems(req *models.CreateSubscriptionRequest) error {
+	seen := make(map[string]bool, len(req.Items))
+	for _, item := range req.Items {
+		if seen[item.PriceID] {
+			return newValidationError("items", "price %s appears on more than one item", item.PriceID)
+		}
+		seen[item.PriceID] = true
+	}
+
+	return nil
+}
+
+// validateSubscriptionTrial checks that a trial end date is in the future and that
+// trial settings are only given for subscriptions that start with a trial
+func validateSubscriptionTrial(req *models.CreateSubscriptionRequest) error {
+	if req.TrialEnd > 0 && req.TrialEnd <= time.Now().Unix() {
+		return newValidationError("trial_end", "must be in the future")
+	}
+
+	if req.TrialSettings != nil && req.TrialPeriodDays == 0 && req.TrialEnd == 0 {
+		return newValidationError("trial_settings", "requires trial_period_days or trial_end")
+	}
+
+	return nil
+}
+
+// buildSubscriptionItemsParams converts the single price or item list of a create
+// request into Stripe subscription items
+func buildSubscriptionItemsParams(req *models.CreateSubscriptionRequest) []*stripe.SubscriptionItemsParams {
+	if len(req.Items) == 0 {
+		item := &stripe.SubscriptionItemsParams{
+			Price: stripe.String(req.PriceID),
+		}
+		if req.Quantity > 0 {
+			item.Quantity = stripe.Int64(req.Quantity)
+		}
+		return []*stripe.SubscriptionItemsParams{item}
+	}
+
+	items := make([]*stripe.SubscriptionItemsParams, 0, len(req.Items))
+	for _, reqItem := range req.Items {
+		item := &stripe.SubscriptionItemsParams{
+			Price:    stripe.String(reqItem.PriceID),
+			Metadata: reqItem.Metadata,
+		}
+		if reqItem.Quantity > 0 {
+			item.Quantity = stripe.Int64(reqItem.Quantity)
+		}
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// CancelSubscription cancels a subscription immediately, or schedules the cancellation
+// for the end of the current period or a given timestamp depending on req.Mode
+func (s *StripeService) CancelSubscription(ctx context.Context, subscriptionID string, req *models.CancelSubscriptionRequest) (*models.Subscription, error) {
+	if err := validateSubscriptionCancellation(req); err != nil {
+		return nil, err
+	}
+
+	switch req.Mode {
+	case "at_period_end":
+		params := &stripe.SubscriptionParams{
+			CancelAtPeriodEnd: stripe.Bool(true),
+		}
+		params.Context = ctx
+
+		stripeSub, err := s.client.Subscriptions.Update(subscriptionID, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to schedule subscription cancellation: %w", err)
+		}
+
+		return s.convertStripeSubscription(stripeSub), nil
+	case "at_timestamp":
+		params := &stripe.SubscriptionParams{
+			CancelAt: stripe.Int64(req.CancelAt),
+		}
+		params.Context = ctx
+
+		if req.Prorate != nil {
+			params.ProrationBehavior = stripe.String("none")
+			if *req.Prorate {
+				params.ProrationBehavior = stripe.String("create_prorations")
+			}
+		}
+
+		stripeSub, err := s.client.Subscriptions.Update(subscriptionID, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to schedule subscription cancellation: %w", err)
+		}
+
+		return s.convertStripeSubscription(stripeSub), nil
+	}
+
+	params := &stripe.SubscriptionCancelParams{
+		Prorate:    req.Prorate,
+		InvoiceNow: req.InvoiceNow,
+	}
+	params.Context = ctx
+
+	stripeSub, err := s.client.Subscriptions.Cancel(subscriptionID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel subscription: %w", err)
+	}
+
+	return s.convertStripeSubscription(stripeSub), nil
+}
+
+// validateSubscriptionCancellation checks that the cancellation options fit the chosen mode
+func validateSubscriptionCancellation(req *models.CancelSubscriptionRequest) error {
+	if req.Mode == "at_timestamp" {
+		if req.CancelAt <= time.Now().Unix() {
+			return newValidationError("cancel_at", "must be in the future")
+		}
+	} else if req.CancelAt != 0 {
+		return newValidationError("cancel_at", "is only allowed when mode is at_timestamp")
+	}
+
+	if req.InvoiceNow != nil && req.Mode != "" && req.Mode != "immediately" {
+		return newValidationError("invoice_now", "is only allowed when mode is immediately")
+	}
+
+	if req.Prorate != nil && req.Mode == "at_period_end" {
+		return newValidationError("prorate", "is not allowed when mode is at_period_end")
+	}
+
+	return nil
+}
+
+// UndoSubscriptionCancellation clears a pending cancellation scheduled with the
+// at_period_end or at_timestamp mode so the subscription renews as usual
+func (s *StripeService) UndoSubscriptionCancellation(ctx context.Context, subscriptionID string) (*models.Subscription, error) {
+	current, err := s.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.Status == string(stripe.SubscriptionStatusCanceled) {
+		return nil, newValidationError("subscription", "is already canceled and cannot be restored")
+	}
+
+	if !current.CancelAtPeriodEnd && current.CancelAt == nil {
+		return nil, newValidationError("subscription", "is not scheduled for cancellation")
+	}
+
+	params := &stripe.SubscriptionParams{}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	if current.CancelAtPeriodEnd {
+		params.CancelAtPeriodEnd = stripe.Bool(false)
+	} else {
+		// An empty cancel_at clears a cancellation scheduled for a specific time
+		params.AddExtra("cancel_at", "")
+	}
+
+	stripeSub, err := s.client.Subscriptions.Update(subscriptionID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to undo subscription cancellation: %w", err)
+	}
+
+	return s.convertStripeSubscription(stripeSub), nil
+}
+
+// GetSubscription retrieves a subscription by ID
+func (s *StripeService) GetSubscription(ctx context.Context, subscriptionID string) (*models.Subscription, error) {
+	params := &stripe.SubscriptionParams{}
+	params.Context = ctx
+
+	stripeSub, err := s.client.Subscriptions.Get(subscriptionID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	return s.convertStripeSubscription(stripeSub), nil
+}
+
+// ListSubscriptions lists a single page of subscriptions filtered by customer, status or price
+func (s *StripeService) ListSubscriptions(ctx context.Context, req *models.ListSubscriptionsRequest) (*models.ListSubscriptionsResponse, error) {
+	params := &stripe.SubscriptionListParams{}
+	params.Context = ctx
+	params.Single = true
+	params.Limit = stripe.Int64(pageLimit(req.Limit))
+
+	if req.Cursor != "" {
+		params.StartingAfter = stripe.String(req.Cursor)
+	}
+
+	if req.CustomerID != "" {
+		params.Customer = stripe.String(req.CustomerID)
+	}
+
+	if req.PriceID != "" {
+		params.Price = stripe.String(req.PriceID)
+	}
+
+	if req.Status != "" {
+		params.Status = stripe.String(req.Status)
+	}
+
+	iter := s.client.Subscriptions.List(params)
+	subscriptions := []models.Subscription{}
+
+	for iter.Next() {
+		subscriptions = append(subscriptions, *s.convertStripeSubscription(iter.Subscription()))
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+
+	response := &models.ListSubscriptionsResponse{
+		Subscriptions: subscriptions,
+		HasMore:       iter.Meta().HasMore,
+	}
+
+	if response.HasMore && len(subscriptions) > 0 {
+		response.NextCursor = subscriptions[len(subscriptions)-1].ID
+	}
+
+	return response, nil
+}
+
+// UpdateSubscription applies a partial update to a subscription's payment and collection settings
+func (s *StripeService) UpdateSubscription(ctx context.Context, subscriptionID string, req *models.UpdateSubscriptionRequest) (*models.Subscription, error) {
+	if err := validateSubscriptionCollection(req); err != nil {
+		return nil, err
+	}
+
+	params := &stripe.SubscriptionParams{}
+	params.Context = ctx
+
+	if req.DefaultPaymentMethodID != nil {
+		params.DefaultPaymentMethod = stripe.String(*req.DefaultPaymentMethodID)
+	}
+
+	if req.CollectionMethod != nil {
+		params.CollectionMethod = stripe.String(*req.CollectionMethod)
+	}
+
+	if req.DaysUntilDue != nil {
+		params.DaysUntilDue = stripe.Int64(*req.DaysUntilDue)
+	}
+
+	addMetadataUpdates(params, req.Metadata)
+
+	stripeSub, err := s.client.Subscriptions.Update(subscriptionID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update subscription: %w", err)
+	}
+
+	return s.convertStripeSubscription(stripeSub), nil
+}
+
+// validateSubscriptionCollection checks that days_until_due accompanies invoice collection only
+func validateSubscriptionCollection(req *models.UpdateSubscriptionRequest) error {
+	if req.CollectionMethod == nil {
+		return nil
+	}
 
-// CreateCustomer creates a new customer in Stripe
-func (s *StripeService) CreateCustomer(ctx context.Context, req *models.CreateCustomerRequest) (*models.Customer, error) {
-	params := &stripe.CustomerParams{
-		Email:       stripe.String(req.Email),
-		Name:        stripe.String(req.Name),
-		Description: stripe.String(req.Description),
+	switch *req.CollectionMethod {
+	case string(stripe.SubscriptionCollectionMethodSendInvoice):
+		if req.DaysUntilDue == nil {
+			return newValidationError("days_until_due", "is required when collection_method is send_invoice")
+		}
+	case string(stripe.SubscriptionCollectionMethodChargeAutomatically):
+		if req.DaysUntilDue != nil {
+			return newValidationError("days_until_due", "is only allowed when collection_method is send_invoice")
+		}
 	}
 
-	// Set context for cancellation support
+	return nil
+}
+
+// ChangeSubscriptionPlan swaps the price on a subscription's item, prorating the change
+// according to req.ProrationBehavior
+func (s *StripeService) ChangeSubscriptionPlan(ctx context.Context, subscriptionID string, req *models.ChangePlanRequest) (*models.Subscription, error) {
+	_, item, err := s.getPlanChangeItem(ctx, subscriptionID, req.PriceID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &stripe.SubscriptionParams{
+		Items: []*stripe.SubscriptionItemsParams{
+			{
+				ID:    stripe.String(item.ID),
+				Price: stripe.String(req.PriceID),
+			},
+		},
+	}
 	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
 
-	if req.Phone != "" {
-		params.Phone = stripe.String(req.Phone)
+	if req.ProrationBehavior != "" {
+		params.ProrationBehavior = stripe.String(req.ProrationBehavior)
 	}
 
-	if req.Metadata != nil {
-		params.Metadata = req.Metadata
+	if req.ProrationDate > 0 {
+		params.ProrationDate = stripe.Int64(req.ProrationDate)
 	}
 
-	stripeCustomer, err := s.client.Customers.New(params)
+	stripeSub, err := s.client.Subscriptions.Update(subscriptionID, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create customer: %w", err)
+		return nil, fmt.Errorf("failed to change subscription plan: %w", err)
 	}
 
-	return s.convertStripeCustomer(stripeCustomer), nil
+	return s.convertStripeSubscription(stripeSub), nil
 }
 
-// GetCustomer retrieves a customer by ID
-func (s *StripeService) GetCustomer(ctx context.Context, customerID string) (*models.Customer, error) {
-	params := &stripe.CustomerParams{}
+// PreviewSubscriptionPlanChange returns the proration lines and amount due on the
+// upcoming invoice if the subscription were switched to req.PriceID
+func (s *StripeService) PreviewSubscriptionPlanChange(ctx context.Context, subscriptionID string, req *models.ChangePlanRequest) (*models.PlanChangePreview, error) {
+	stripeSub, item, err := s.getPlanChangeItem(ctx, subscriptionID, req.PriceID)
+	if err != nil {
+		return nil, err
+	}
+
+	prorationDate := req.ProrationDate
+	if prorationDate == 0 {
+		prorationDate = time.Now().Unix()
+	}
+
+	params := &stripe.InvoiceUpcomingParams{
+		Customer:     stripe.String(stripeSub.Customer.ID),
+		Subscription: stripe.String(subscriptionID),
+		SubscriptionItems: []*stripe.SubscriptionItemsParams{
+			{
+				ID:    stripe.String(item.ID),
+				Price: stripe.String(req.PriceID),
+			},
+		},
+		SubscriptionProrationDate: stripe.Int64(prorationDate),
+	}
 	params.Context = ctx
 
-	stripeCustomer, err := s.client.Customers.Get(customerID, params)
+	if req.ProrationBehavior != "" {
+		params.SubscriptionProrationBehavior = stripe.String(req.ProrationBehavior)
+	}
+
+	invoice, err := s.client.Invoices.Upcoming(params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get customer: %w", err)
+		return nil, fmt.Errorf("failed to preview plan change: %w", err)
 	}
 
-	return s.convertStripeCustomer(stripeCustomer), nil
+	preview := s.convertPlanChangePreview(invoice)
+	preview.SubscriptionID = subscriptionID
+	preview.PriceID = req.PriceID
+	preview.ProrationDate = prorationDate
+
+	return preview, nil
 }
 
-// ListCustomers lists customers with pagination
-func (s *StripeService) ListCustomers(ctx context.Context, req *models.ListCustomersRequest) (*models.ListCustomersResponse, error) {
-	params := &stripe.CustomerListParams{}
+// getPlanChangeItem fetches a subscription and the item whose price a plan change replaces
+func (s *StripeService) getPlanChangeItem(ctx context.Context, subscriptionID, priceID string) (*stripe.Subscription, *stripe.SubscriptionItem, error) {
+	params := &stripe.SubscriptionParams{}
 	params.Context = ctx
 
-	if req.Limit > 0 {
-		params.Limit = stripe.Int64(req.Limit)
-	} else {
-		params.Limit = stripe.Int64(DefaultCustomerLimit)
+	stripeSub, err := s.client.Subscriptions.Get(subscriptionID, params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get subscription: %w", err)
 	}
 
-	if req.Cursor != "" {
-		params.StartingAfter = stripe.String(req.Cursor)
+	if stripeSub.Status == stripe.SubscriptionStatusCanceled {
+		return nil, nil, newValidationError("subscription", "is canceled and cannot change plans")
 	}
 
-	iter := s.client.Customers.List(params)
-	var customers []models.Customer
+	if stripeSub.Items == nil || len(stripeSub.Items.Data) == 0 {
+		return nil, nil, newValidationError("subscription", "has no items to change")
+	}
 
-	for iter.Next() {
-		customers = append(customers, *s.convertStripeCustomer(iter.Customer()))
+	if len(stripeSub.Items.Data) > 1 {
+		return nil, nil, newValidationError("subscription", "has multiple items; update the price on a specific item instead")
 	}
 
-	if err := iter.Err(); err != nil {
-		return nil, fmt.Errorf("failed to list customers: %w", err)
+	item := stripeSub.Items.Data[0]
+	if item.Price != nil && item.Price.ID == priceID {
+		return nil, nil, newValidationError("price_id", "is already the subscription's price")
 	}
 
-	return &models.ListCustomersResponse{
-		Customers: customers,
-		HasMore:   iter.Meta().HasMore,
-	}, nil
+	return stripeSub, item, nil
 }
 
-// Payment operations
-
-// CreatePaymentIntent creates a new payment intent
-func (s *StripeService) CreatePaymentIntent(ctx context.Context, req *models.CreatePaymentIntentRequest) (*models.PaymentIntent, error) {
-	params := &stripe.PaymentIntentParams{
-		Amount:   stripe.Int64(req.Amount),
-		Currency: stripe.String(req.Currency),
+// AddSubscriptionItem adds a price to an existing subscription
+func (s *StripeService) AddSubscriptionItem(ctx context.Context, subscriptionID string, req *models.AddSubscriptionItemRequest) (*models.SubscriptionItem, error) {
+	params := &stripe.SubscriptionItemParams{
+		Subscription: stripe.String(subscriptionID),
+		Price:        stripe.String(req.PriceID),
 	}
 	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
 
-	if req.CustomerID != "" {
-		params.Customer = stripe.String(req.CustomerID)
+	if req.Quantity > 0 {
+		params.Quantity = stripe.Int64(req.Quantity)
 	}
 
-	if req.Description != "" {
-		params.Description = stripe.String(req.Description)
+	if req.ProrationBehavior != "" {
+		params.ProrationBehavior = stripe.String(req.ProrationBehavior)
 	}
 
 	if req.Metadata != nil {
 		params.Metadata = req.Metadata
 	}
 
-	if req.PaymentMethodID != "" {
-		params.PaymentMethod = stripe.String(req.PaymentMethodID)
+	stripeItem, err := s.client.SubscriptionItems.New(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add subscription item: %w", err)
 	}
 
-	if req.ConfirmationMethod != "" {
-		params.ConfirmationMethod = stripe.String(req.ConfirmationMethod)
+	return s.convertStripeSubscriptionItem(stripeItem), nil
+}
+
+// UpdateSubscriptionItem applies a partial update to an item on the given subscription
+func (s *StripeService) UpdateSubscriptionItem(ctx context.Context, subscriptionID, itemID string, req *models.UpdateSubscriptionItemRequest) (*models.SubscriptionItem, error) {
+	if _, err := s.getSubscriptionItem(ctx, subscriptionID, itemID); err != nil {
+		return nil, err
 	}
 
-	stripePI, err := s.client.PaymentIntents.New(params)
+	params := &stripe.SubscriptionItemParams{}
+	params.Context = ctx
+
+	if req.PriceID != nil {
+		params.Price = stripe.String(*req.PriceID)
+	}
+
+	if req.Quantity != nil {
+		params.Quantity = stripe.Int64(*req.Quantity)
+	}
+
+	if req.ProrationBehavior != "" {
+		params.ProrationBehavior = stripe.String(req.ProrationBehavior)
+	}
+
+	addMetadataUpdates(params, req.Metadata)
+
+	stripeItem, err := s.client.SubscriptionItems.Update(itemID, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create payment intent: %w", err)
+		return nil, fmt.Errorf("failed to update subscription item: %w", err)
 	}
 
-	return s.convertStripePaymentIntent(stripePI), nil
+	return s.convertStripeSubscriptionItem(stripeItem), nil
 }
 
-// ConfirmPaymentIntent confirms a payment intent
-func (s *StripeService) ConfirmPaymentIntent(ctx context.Context, paymentIntentID string, req *models.ConfirmPaymentIntentRequest) (*models.PaymentIntent, error) {
-	params := &stripe.PaymentIntentConfirmParams{}
+// RemoveSubscriptionItem removes an item from the given subscription. A subscription
+// must keep at least one item; cancel the subscription instead of removing its last item.
+func (s *StripeService) RemoveSubscriptionItem(ctx context.Context, subscriptionID, itemID string, req *models.RemoveSubscriptionItemRequest) (*models.DeletedSubscriptionItem, error) {
+	if _, err := s.getSubscriptionItem(ctx, subscriptionID, itemID); err != nil {
+		return nil, err
+	}
+
+	hasOthers, err := s.hasOtherSubscriptionItems(ctx, subscriptionID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasOthers {
+		return nil, newValidationError("item_id", "is the subscription's only item; cancel the subscription instead")
+	}
+
+	params := &stripe.SubscriptionItemParams{}
 	params.Context = ctx
 
-	if req.PaymentMethodID != "" {
-		params.PaymentMethod = stripe.String(req.PaymentMethodID)
+	if req.ProrationBehavior != "" {
+		params.ProrationBehavior = stripe.String(req.ProrationBehavior)
 	}
 
-	if req.ReturnURL != "" {
-		params.ReturnURL = stripe.String(req.ReturnURL)
+	if req.ClearUsage {
+		params.ClearUsage = stripe.Bool(true)
 	}
 
-	stripePI, err := s.client.PaymentIntents.Confirm(paymentIntentID, params)
+	stripeItem, err := s.client.SubscriptionItems.Del(itemID, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to confirm payment intent: %w", err)
+		return nil, fmt.Errorf("failed to remove subscription item: %w", err)
 	}
 
-	return s.convertStripePaymentIntent(stripePI), nil
+	return &models.DeletedSubscriptionItem{
+		ID:      stripeItem.ID,
+		Deleted: stripeItem.Deleted,
+	}, nil
 }
 
-// Product operations
-
-// CreateProduct creates a new product
-func (s *StripeService) CreateProduct(ctx context.Context, req *models.CreateProductRequest) (*models.Product, error) {
-	params := &stripe.ProductParams{
-		Name:        stripe.String(req.Name),
-		Description: stripe.String(req.Description),
-		Active:      stripe.Bool(req.Active),
-	}
+// getSubscriptionItem fetches an item and checks that it belongs to the given subscription
+func (s *StripeService) getSubscriptionItem(ctx context.Context, subscriptionID, itemID string) (*stripe.SubscriptionItem, error) {
+	params := &stripe.SubscriptionItemParams{}
 	params.Context = ctx
 
-	if req.Metadata != nil {
-		params.Metadata = req.Metadata
+	stripeItem, err := s.client.SubscriptionItems.Get(itemID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription item: %w", err)
 	}
 
-	stripeProduct, err := s.client.Products.New(params)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create product: %w", err)
+	if stripeItem.Subscription != subscriptionID {
+		return nil, newValidationError("item_id", "does not belong to subscription %s", subscriptionID)
 	}
 
-	return s.convertStripeProduct(stripeProduct), nil
+	return stripeItem, nil
 }
 
-// CreatePrice creates a new price
-func (s *StripeService) CreatePrice(ctx context.Context, req *models.CreatePriceRequest) (*models.Price, error) {
-	params := &stripe.PriceParams{
-		Product:    stripe.String(req.ProductID),
-		UnitAmount: stripe.Int64(req.UnitAmount),
-		Currency:   stripe.String(req.Currency),
-		Active:     stripe.Bool(req.Active),
+// hasOtherSubscriptionItems reports whether the subscription has an item besides itemID.
+// Two items are enough to decide, so only the first page of two is requested.
+func (s *StripeService) hasOtherSubscriptionItems(ctx context.Context, subscriptionID, itemID string) (bool, error) {
+	params := &stripe.SubscriptionItemListParams{
+		Subscription: stripe.String(subscriptionID),
 	}
 	params.Context = ctx
+	params.Limit = stripe.Int64(2)
+	params.Single = true
 
-	if req.Type == "recurring" && req.RecurringInterval != "" {
-		params.Recurring = &stripe.PriceRecurringParams{
-			Interval: stripe.String(req.RecurringInterval),
+	iter := s.client.SubscriptionItems.List(params)
+	for iter.Next() {
+		if iter.SubscriptionItem().ID != itemID {
+			return true, nil
 		}
 	}
 
-	if req.Metadata != nil {
-		params.Metadata = req.Metadata
+	if err := iter.Err(); err != nil {
+		return false, fmt.Errorf("failed to list subscription items: %w", err)
 	}
 
-	stripePrice, err := s.client.Prices.New(params)
+	return false, nil
+}
+
+// EndSubscriptionTrial ends a subscription's trial immediately, starting the first paid
+// billing period
+func (s *StripeService) EndSubscriptionTrial(ctx context.Context, subscriptionID string) (*models.Subscription, error) {
+	current, err := s.GetSubscription(ctx, subscriptionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create price: %w", err)
+		return nil, err
 	}
 
-	return s.convertStripePrice(stripePrice), nil
+	if current.Status != string(stripe.SubscriptionStatusTrialing) {
+		return nil, newValidationError("subscription", "is not in a trial")
+	}
+
+	params := &stripe.SubscriptionParams{
+		TrialEndNow: stripe.Bool(true),
+	}
+	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+
+	stripeSub, err := s.client.Subscriptions.Update(subscriptionID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to end subscription trial: %w", err)
+	}
+
+	return s.convertStripeSubscription(stripeSub), nil
 }
 
-// Subscription operations
+// PauseSubscription pauses payment collection on a subscription. The subscription
+// stays active while its invoices are handled according to the pause behavior.
+func (s *StripeService) PauseSubscription(ctx context.Context, subscriptionID string, req *models.PauseSubscriptionRequest) (*models.Subscription, error) {
+	behavior := req.Behavior
+	if behavior == "" {
+		behavior = string(stripe.SubscriptionPauseCollectionBehaviorVoid)
+	}
+
+	if req.ResumesAt > 0 && req.ResumesAt <= time.Now().Unix() {
+		return nil, newValidationError("resumes_at", "must be in the future")
+	}
 
-// CreateSubscription creates a new subscription
-func (s *StripeService) CreateSubscription(ctx context.Context, req *models.CreateSubscriptionRequest) (*models.Subscription, error) {
 	params := &stripe.SubscriptionParams{
-		Customer: stripe.String(req.CustomerID),
-		Items: []*stripe.SubscriptionItemsParams{
-			{
-				Price: stripe.String(req.PriceID),
-			},
+		PauseCollection: &stripe.SubscriptionPauseCollectionParams{
+			Behavior: stripe.String(behavior),
 		},
 	}
 	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
 
-	if req.Metadata != nil {
-		params.Metadata = req.Metadata
+	if req.ResumesAt > 0 {
+		params.PauseCollection.ResumesAt = stripe.Int64(req.ResumesAt)
 	}
 
-	stripeSub, err := s.client.Subscriptions.New(params)
+	stripeSub, err := s.client.Subscriptions.Update(subscriptionID, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create subscription: %w", err)
+		return nil, fmt.Errorf("failed to pause subscription: %w", err)
 	}
 
 	return s.convertStripeSubscription(stripeSub), nil
 }
 
-// CancelSubscription cancels a subscription
-func (s *StripeService) CancelSubscription(ctx context.Context, subscriptionID string) (*models.Subscription, error) {
-	params := &stripe.SubscriptionCancelParams{}
+// ResumeSubscription resumes payment collection on a paused subscription
+func (s *StripeService) ResumeSubscription(ctx context.Context, subscriptionID string) (*models.Subscription, error) {
+	params := &stripe.SubscriptionParams{}
 	params.Context = ctx
+	setIdempotencyKey(ctx, &params.Params)
+	// An empty pause_collection clears the pause
+	params.AddExtra("pause_collection", "")
 
-	stripeSub, err := s.client.Subscriptions.Cancel(subscriptionID, params)
+	stripeSub, err := s.client.Subscriptions.Update(subscriptionID, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to cancel subscription: %w", err)
+		return nil, fmt.Errorf("failed to resume subscription: %w", err)
 	}
 
 	return s.convertStripeSubscription(stripeSub), nil
@@ -269,6 +1833,7 @@ type StripeCustomer interface {
 	GetPhone() string
 	GetDescription() string
 	GetMetadata() map[string]string
+	GetDefaultPaymentMethodID() string
 	GetCreated() int64
 }
 
@@ -319,6 +1884,13 @@ func (a *stripeCustomerAdapter) GetMetadata() map[string]string {
 	return a.customer.Metadata
 }
 
+func (a *stripeCustomerAdapter) GetDefaultPaymentMethodID() string {
+	if a.customer == nil || a.customer.InvoiceSettings == nil || a.customer.InvoiceSettings.DefaultPaymentMethod == nil {
+		return ""
+	}
+	return a.customer.InvoiceSettings.DefaultPaymentMethod.ID
+}
+
 func (a *stripeCustomerAdapter) GetCreated() int64 {
 	if a.customer == nil {
 		return 0
@@ -339,14 +1911,15 @@ func (s *StripeService) convertStripeCustomerInterface(stripeCustomer StripeCust
 	createdAt := time.Unix(stripeCustomer.GetCreated(), 0)
 
 	return &models.Customer{
-		ID:          stripeCustomer.GetID(),
-		Email:       stripeCustomer.GetEmail(),
-		Name:        stripeCustomer.GetName(),
-		Phone:       stripeCustomer.GetPhone(),
-		Description: stripeCustomer.GetDescription(),
-		Metadata:    stripeCustomer.GetMetadata(),
-		CreatedAt:   createdAt,
-		UpdatedAt:   createdAt, // Stripe doesn't provide separate updated_at
+		ID:                     stripeCustomer.GetID(),
+		Email:                  stripeCustomer.GetEmail(),
+		Name:                   stripeCustomer.GetName(),
+		Phone:                  stripeCustomer.GetPhone(),
+		Description:            stripeCustomer.GetDescription(),
+		Metadata:               stripeCustomer.GetMetadata(),
+		DefaultPaymentMethodID: stripeCustomer.GetDefaultPaymentMethodID(),
+		CreatedAt:              createdAt,
+		UpdatedAt:              createdAt, // Stripe doesn't provide separate updated_at
 	}
 }
 
@@ -359,20 +1932,103 @@ func (s *StripeService) convertStripePaymentIntent(stripePI *stripe.PaymentInten
 		customerID = stripePI.Customer.ID
 	}
 
+	paymentMethodID := ""
+	if stripePI.PaymentMethod != nil {
+		paymentMethodID = stripePI.PaymentMethod.ID
+	}
+
+	var captureBefore int64
+	if stripePI.LatestCharge != nil && stripePI.LatestCharge.PaymentMethodDetails != nil && stripePI.LatestCharge.PaymentMethodDetails.Card != nil {
+		captureBefore = stripePI.LatestCharge.PaymentMethodDetails.Card.CaptureBefore
+	}
+
 	createdAt := time.Unix(stripePI.Created, 0)
 
 	return &models.PaymentIntent{
-		ID:           stripePI.ID,
-		Amount:       stripePI.Amount,
-		Currency:     string(stripePI.Currency),
-		Status:       string(stripePI.Status),
-		CustomerID:   customerID,
-		Description:  stripePI.Description,
-		Metadata:     stripePI.Metadata,
-		ClientSecret: stripePI.ClientSecret,
-		CreatedAt:    createdAt,
-		UpdatedAt:    createdAt,
+		ID:                 stripePI.ID,
+		Amount:             stripePI.Amount,
+		Currency:           string(stripePI.Currency),
+		Status:             string(stripePI.Status),
+		CustomerID:         customerID,
+		Description:        stripePI.Description,
+		Metadata:           stripePI.Metadata,
+		ClientSecret:       stripePI.ClientSecret,
+		PaymentMethodID:    paymentMethodID,
+		ConfirmationMethod: string(stripePI.ConfirmationMethod),
+		CaptureMethod:      string(stripePI.CaptureMethod),
+		AmountCapturable:   stripePI.AmountCapturable,
+		AmountReceived:     stripePI.AmountReceived,
+		CaptureBefore:      optionalTime(captureBefore),
+		CancellationReason: string(stripePI.CancellationReason),
+		CanceledAt:         optionalTime(stripePI.CanceledAt),
+		CreatedAt:          createdAt,
+		UpdatedAt:          createdAt,
+	}
+}
+
+// metadataParams is implemented by every Stripe params type that carries metadata
+type metadataParams interface {
+	AddMetadata(key, value string)
+}
+
+// addMetadataUpdates merges metadata into update params. Keys not in the request keep
+// their current value, and Stripe removes keys that are sent with an empty value.
+func addMetadataUpdates(params metadataParams, metadata map[string]string) {
+	for key, value := range metadata {
+		params.AddMetadata(key, value)
+	}
+}
+
+// optionalTime converts a Stripe timestamp that may be unset
+func optionalTime(timestamp int64) *time.Time {
+	if timestamp == 0 {
+		return nil
+	}
+	t := time.Unix(timestamp, 0)
+	return &t
+}
+
+func (s *StripeService) convertPlanChangePreview(invoice *stripe.Invoice) *models.PlanChangePreview {
+	if invoice == nil {
+		return nil
+	}
+
+	preview := &models.PlanChangePreview{
+		Currency:  string(invoice.Currency),
+		AmountDue: invoice.AmountDue,
+		Lines:     []models.ProrationLine{},
+	}
+
+	if invoice.Lines == nil {
+		return preview
+	}
+
+	for _, line := range invoice.Lines.Data {
+		if !line.Proration {
+			continue
+		}
+
+		prorationLine := models.ProrationLine{
+			Description: line.Description,
+			Amount:      line.Amount,
+			Currency:    string(line.Currency),
+			Quantity:    line.Quantity,
+		}
+
+		if line.Price != nil {
+			prorationLine.PriceID = line.Price.ID
+		}
+
+		if line.Period != nil {
+			prorationLine.PeriodStart = time.Unix(line.Period.Start, 0)
+			prorationLine.PeriodEnd = time.Unix(line.Period.End, 0)
+		}
+
+		preview.Lines = append(preview.Lines, prorationLine)
+		preview.ProrationAmount += line.Amount
 	}
+
+	return preview
 }
 
 func (s *StripeService) convertStripeProduct(stripeProduct *stripe.Product) *models.Product {
@@ -385,15 +2041,22 @@ func (s *StripeService) convertStripeProduct(stripeProduct *stripe.Product) *mod
 		updatedAt = time.Unix(stripeProduct.Updated, 0)
 	}
 
-	return &models.Product{
+	product := &models.Product{
 		ID:          stripeProduct.ID,
 		Name:        stripeProduct.Name,
 		Description: stripeProduct.Description,
+		Images:      stripeProduct.Images,
 		Active:      stripeProduct.Active,
 		Metadata:    stripeProduct.Metadata,
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
 	}
+
+	if stripeProduct.DefaultPrice != nil {
+		product.DefaultPriceID = stripeProduct.DefaultPrice.ID
+	}
+
+	return product
 }
 
 func (s *StripeService) convertStripePrice(stripePrice *stripe.Price) *models.Price {
@@ -402,26 +2065,71 @@ func (s *StripeService) convertStripePrice(stripePrice *stripe.Price) *models.Pr
 	}
 	createdAt := time.Unix(stripePrice.Created, 0)
 
-	priceType := "one_time"
-	recurringInterval := ""
+	price := &models.Price{
+		ID:            stripePrice.ID,
+		ProductID:     stripePrice.Product.ID,
+		UnitAmount:    stripePrice.UnitAmount,
+		Currency:      string(stripePrice.Currency),
+		Type:          "one_time",
+		BillingScheme: string(stripePrice.BillingScheme),
+		TiersMode:     string(stripePrice.TiersMode),
+		Nickname:      stripePrice.Nickname,
+		LookupKey:     stripePrice.LookupKey,
+		Active:        stripePrice.Active,
+		Metadata:      stripePrice.Metadata,
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+	}
+
+	// Stripe repeats the default currency in currency_options; only the additional ones are exposed
+	for currency, option := range stripePrice.CurrencyOptions {
+		if currency == string(stripePrice.Currency) || option == nil {
+			continue
+		}
+		if price.CurrencyOptions == nil {
+			price.CurrencyOptions = make(map[string]models.CurrencyOption)
+		}
+		price.CurrencyOptions[currency] = models.CurrencyOption{UnitAmount: option.UnitAmount}
+	}
+
+	for _, tier := range stripePrice.Tiers {
+		price.Tiers = append(price.Tiers, convertStripePriceTier(tier))
+	}
+
+	if stripePrice.TransformQuantity != nil {
+		price.TransformQuantity = &models.TransformQuantity{
+			DivideBy: stripePrice.TransformQuantity.DivideBy,
+			Round:    string(stripePrice.TransformQuantity.Round),
+		}
+	}
+
+	if recurring := stripePrice.Recurring; recurring != nil {
+		price.Type = "recurring"
+		price.RecurringInterval = string(recurring.Interval)
+		price.IntervalCount = recurring.IntervalCount
+		price.UsageType = string(recurring.UsageType)
+		price.AggregateUsage = string(recurring.AggregateUsage)
+		price.TrialPeriodDays = recurring.TrialPeriodDays
+	}
+
+	return price
+}
 
-	if stripePrice.Recurring != nil {
-		priceType = "recurring"
-		recurringInterval = string(stripePrice.Recurring.Interval)
+// convertStripePriceTier converts a Stripe price tier, where a missing up_to marks the final tier.
+// stripe-go decodes a null amount (and its *_decimal twin) as 0, so an unset amount cannot be told
+// apart from a free tier; both amounts are always reported so that a 0 amount is never dropped.
+func convertStripePriceTier(tier *stripe.PriceTier) models.PriceTier {
+	converted := models.PriceTier{
+		UpTo:       models.TierUpTo(tier.UpTo),
+		UnitAmount: stripe.Int64(tier.UnitAmount),
+		FlatAmount: stripe.Int64(tier.FlatAmount),
 	}
 
-	return &models.Price{
-		ID:                stripePrice.ID,
-		ProductID:         stripePrice.Product.ID,
-		UnitAmount:        stripePrice.UnitAmount,
-		Currency:          string(stripePrice.Currency),
-		Type:              priceType,
-		RecurringInterval: recurringInterval,
-		Active:            stripePrice.Active,
-		Metadata:          stripePrice.Metadata,
-		CreatedAt:         createdAt,
-		UpdatedAt:         createdAt,
+	if tier.UpTo == 0 {
+		converted.UpTo = models.TierUpToInf
 	}
+
+	return converted
 }
 
 func (s *StripeService) convertStripeSubscription(stripeSub *stripe.Subscription) *models.Subscription {
@@ -430,15 +2138,76 @@ func (s *StripeService) convertStripeSubscription(stripeSub *stripe.Subscription
 	}
 	createdAt := time.Unix(stripeSub.Created, 0)
 
-	return &models.Subscription{
+	subscription := &models.Subscription{
 		ID:                 stripeSub.ID,
-		CustomerID:         stripeSub.Customer.ID,
-		PriceID:            stripeSub.Items.Data[0].Price.ID,
+		Items:              []models.SubscriptionItem{},
 		Status:             string(stripeSub.Status),
 		CurrentPeriodStart: time.Unix(stripeSub.CurrentPeriodStart, 0),
 		CurrentPeriodEnd:   time.Unix(stripeSub.CurrentPeriodEnd, 0),
+		CancelAtPeriodEnd:  stripeSub.CancelAtPeriodEnd,
+		CancelAt:           optionalTime(stripeSub.CancelAt),
+		CanceledAt:         optionalTime(stripeSub.CanceledAt),
+		TrialStart:         optionalTime(stripeSub.TrialStart),
+		TrialEnd:           optionalTime(stripeSub.TrialEnd),
+		CollectionMethod:   string(stripeSub.CollectionMethod),
+		DaysUntilDue:       stripeSub.DaysUntilDue,
 		Metadata:           stripeSub.Metadata,
 		CreatedAt:          createdAt,
 		UpdatedAt:          createdAt,
 	}
+
+	if stripeSub.PauseCollection != nil {
+		subscription.PauseCollection = &models.SubscriptionPause{
+			Behavior:  string(stripeSub.PauseCollection.Behavior),
+			ResumesAt: optionalTime(stripeSub.PauseCollection.ResumesAt),
+		}
+	}
+
+	if stripeSub.Customer != nil {
+		subscription.CustomerID = stripeSub.Customer.ID
+	}
+
+	if stripeSub.TrialSettings != nil && stripeSub.TrialSettings.EndBehavior != nil {
+		subscription.TrialSettings = &models.SubscriptionTrialSettings{
+			EndBehavior: models.SubscriptionTrialEndBehavior{
+				MissingPaymentMethod: string(stripeSub.TrialSettings.EndBehavior.MissingPaymentMethod),
+			},
+		}
+	}
+
+	if stripeSub.DefaultPaymentMethod != nil {
+		subscription.DefaultPaymentMethodID = stripeSub.DefaultPaymentMethod.ID
+	}
+
+	if stripeSub.Items != nil {
+		for _, stripeItem := range stripeSub.Items.Data {
+			subscription.Items = append(subscription.Items, *s.convertStripeSubscriptionItem(stripeItem))
+		}
+	}
+
+	// PriceID mirrors the first item for clients that predate multi-item subscriptions
+	if len(subscription.Items) > 0 {
+		subscription.PriceID = subscription.Items[0].PriceID
+	}
+
+	return subscription
+}
+
+func (s *StripeService) convertStripeSubscriptionItem(stripeItem *stripe.SubscriptionItem) *models.SubscriptionItem {
+	if stripeItem == nil {
+		return nil
+	}
+
+	item := &models.SubscriptionItem{
+		ID:        stripeItem.ID,
+		Quantity:  stripeItem.Quantity,
+		Metadata:  stripeItem.Metadata,
+		CreatedAt: time.Unix(stripeItem.Created, 0),
+	}
+
+	if stripeItem.Price != nil {
+		item.PriceID = stripeItem.Price.ID
+	}
+
+	return item
 }
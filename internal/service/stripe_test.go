@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v76"
 )
 
 func TestNewStripeService(t *testing.T) {
@@ -86,6 +87,145 @@ func TestStripeService_ListCustomersDefaultLimit(t *testing.T) {
 	require.Contains(t, err.Error(), "failed to list customers", "Expected specific error message")
 }
 
+func TestCustomerPageLimit(t *testing.T) {
+	assert.Equal(t, int64(DefaultCustomerLimit), customerPageLimit(0), "Expected default limit when unset")
+	assert.Equal(t, int64(DefaultCustomerLimit), customerPageLimit(-5), "Expected default limit when negative")
+	assert.Equal(t, int64(25), customerPageLimit(25))
+	assert.Equal(t, int64(MaxCustomerLimit), customerPageLimit(MaxCustomerLimit+1), "Expected limit to be capped")
+}
+
+func TestCustomerPageCursors(t *testing.T) {
+	page := []models.Customer{{ID: "cus_3"}, {ID: "cus_2"}, {ID: "cus_1"}}
+
+	tests := []struct {
+		name         string
+		customers    []models.Customer
+		hasMore      bool
+		req          *models.ListCustomersRequest
+		expectedNext string
+		expectedPrev string
+	}{
+		{
+			name:         "first page with more",
+			customers:    page,
+			hasMore:      true,
+			req:          &models.ListCustomersRequest{},
+			expectedNext: "cus_1",
+		},
+		{
+			name:      "only page",
+			customers: page,
+			hasMore:   false,
+			req:       &models.ListCustomersRequest{},
+		},
+		{
+			name:         "middle page forward",
+			customers:    page,
+			hasMore:      true,
+			req:          &models.ListCustomersRequest{Cursor: "cus_4"},
+			expectedNext: "cus_1",
+			expectedPrev: "cus_3",
+		},
+		{
+			name:         "last page forward",
+			customers:    page,
+			hasMore:      false,
+			req:          &models.ListCustomersRequest{Cursor: "cus_4"},
+			expectedPrev: "cus_3",
+		},
+		{
+			name:         "backward with more newer customers",
+			customers:    page,
+			hasMore:      true,
+			req:          &models.ListCustomersRequest{EndingBefore: "cus_0"},
+			expectedNext: "cus_1",
+			expectedPrev: "cus_3",
+		},
+		{
+			name:         "backward reaching newest customer",
+			customers:    page,
+			hasMore:      false,
+			req:          &models.ListCustomersRequest{EndingBefore: "cus_0"},
+			expectedNext: "cus_1",
+		},
+		{
+			name:      "empty page",
+			customers: []models.Customer{},
+			hasMore:   false,
+			req:       &models.ListCustomersRequest{Cursor: "cus_4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, prev := customerPageCursors(tt.customers, tt.hasMore, tt.req)
+			assert.Equal(t, tt.expectedNext, next)
+			assert.Equal(t, tt.expectedPrev, prev)
+		})
+	}
+}
+
+func TestIsCustomerSearch(t *testing.T) {
+	assert.False(t, isCustomerSearch(&models.ListCustomersRequest{}))
+	assert.False(t, isCustomerSearch(&models.ListCustomersRequest{Email: "a@example.com", CreatedGte: 1}))
+	assert.True(t, isCustomerSearch(&models.ListCustomersRequest{Query: "name~'jane'"}))
+	assert.True(t, isCustomerSearch(&models.ListCustomersRequest{Page: "page_token"}))
+	assert.True(t, isCustomerSearch(&models.ListCustomersRequest{Metadata: map[string]string{"tier": "gold"}}))
+}
+
+func TestBuildCustomerSearchQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *models.ListCustomersRequest
+		expected string
+	}{
+		{
+			name:     "raw query only",
+			req:      &models.ListCustomersRequest{Query: "name~'jane'"},
+			expected: "name~'jane'",
+		},
+		{
+			name: "filters combined with query",
+			req: &models.ListCustomersRequest{
+				Query:      "name~'jane'",
+				Email:      "jane@example.com",
+				CreatedGte: 1640995200,
+				CreatedLte: 1672531200,
+				Metadata:   map[string]string{"tier": "gold", "region": "eu"},
+			},
+			expected: "name~'jane' AND email:'jane@example.com' AND created>=1640995200 AND created<=1672531200 AND metadata['region']:'eu' AND metadata['tier']:'gold'",
+		},
+		{
+			name:     "quotes are escaped",
+			req:      &models.ListCustomersRequest{Metadata: map[string]string{"owner": "o'brien"}},
+			expected: `metadata['owner']:'o\'brien'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, buildCustomerSearchQuery(tt.req))
+		})
+	}
+}
+
+func TestStripeService_SearchCustomers(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+
+	// This will fail with the test key, but we're testing the search path is taken
+	_, err := service.ListCustomers(context.Background(), &models.ListCustomersRequest{
+		Metadata: map[string]string{"tier": "gold"},
+	})
+
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to search customers")
+}
+
 func TestStripeService_ContextUsage(t *testing.T) {
 	cfg := &config.Config{
 		Stripe: config.StripeConfig{
@@ -141,22 +281,65 @@ func TestStripeService_ServiceInterface(t *testing.T) {
 // Mock types for testing
 
 type mockStripeCustomer struct {
-	ID          string
-	Email       string
-	Name        string
-	Phone       string
-	Description string
-	Metadata    map[string]string
-	Created     int64
-}
-
-func (m *mockStripeCustomer) GetID() string                  { return m.ID }
-func (m *mockStripeCustomer) GetEmail() string               { return m.Email }
-func (m *mockStripeCustomer) GetName() string                { return m.Name }
-func (m *mockStripeCustomer) GetPhone() string               { return m.Phone }
-func (m *mockStripeCustomer) GetDescription() string         { return m.Description }
-func (m *mockStripeCustomer) GetMetadata() map[string]string { return m.Metadata }
-func (m *mockStripeCustomer) GetCreated() int64              { return m.Created }
+	ID                     string
+	Email                  string
+	Name                   string
+	Phone                  string
+	Description            string
+	Metadata               map[string]string
+	DefaultPaymentMethodID string
+	Created                int64
+}
+
+func (m *mockStripeCustomer) GetID() string                     { return m.ID }
+func (m *mockStripeCustomer) GetEmail() string                  { return m.Email }
+func (m *mockStripeCustomer) GetName() string                   { return m.Name }
+func (m *mockStripeCustomer) GetPhone() string                  { return m.Phone }
+func (m *mockStripeCustomer) GetDescription() string            { return m.Description }
+func (m *mockStripeCustomer) GetMetadata() map[string]string    { return m.Metadata }
+func (m *mockStripeCustomer) GetDefaultPaymentMethodID() string { return m.DefaultPaymentMethodID }
+func (m *mockStripeCustomer) GetCreated() int64                 { return m.Created }
+
+func TestStripeService_UpdateCustomer(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+
+	ctx := context.Background()
+	name := "Jane Doe"
+	req := &models.UpdateCustomerRequest{
+		Name:     &name,
+		Metadata: map[string]string{"legacy": ""},
+	}
+
+	// This will fail with the test key, but we're testing the method exists and handles errors
+	result, err := service.UpdateCustomer(ctx, "cus_test_123", req)
+
+	assert.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to update customer")
+	assert.Nil(t, result, "Expected nil result on error")
+}
+
+func TestStripeService_DeleteCustomer(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+
+	ctx := context.Background()
+
+	// This will fail with the test key, but we're testing the method exists and handles errors
+	result, err := service.DeleteCustomer(ctx, "cus_test_123")
+
+	assert.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to delete customer")
+	assert.Nil(t, result, "Expected nil result on error")
+}
 
 // Test missing service methods
 func TestStripeService_CreatePaymentIntent(t *testing.T) {
@@ -181,6 +364,70 @@ func TestStripeService_CreatePaymentIntent(t *testing.T) {
 	assert.Nil(t, result, "Expected nil result on error")
 }
 
+func TestStripeService_CreatePaymentIntentCurrency(t *testing.T) {
+	service := NewStripeService(&config.Config{Stripe: config.StripeConfig{SecretKey: "sk_test_123"}})
+
+	// Unknown currencies are rejected before calling Stripe
+	result, err := service.CreatePaymentIntent(context.Background(), &models.CreatePaymentIntentRequest{
+		Amount:   1000,
+		Currency: "abc",
+	})
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "currency", validationErr.Field)
+	assert.Nil(t, result)
+
+	// Pricing by price ID needs the price, which fails with the test key
+	result, err = service.CreatePaymentIntent(context.Background(), &models.CreatePaymentIntentRequest{
+		Currency: "eur",
+		PriceID:  "price_test_123",
+		Quantity: 2,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create payment intent")
+	assert.Nil(t, result)
+}
+
+func TestPaymentAmountForPrice(t *testing.T) {
+	price := &stripe.Price{
+		ID:            "price_123",
+		Type:          stripe.PriceTypeOneTime,
+		BillingScheme: stripe.PriceBillingSchemePerUnit,
+		Currency:      stripe.CurrencyUSD,
+		UnitAmount:    1000,
+		CurrencyOptions: map[string]*stripe.PriceCurrencyOptions{
+			"usd": {UnitAmount: 1000},
+			"eur": {UnitAmount: 900},
+			"gbp": {UnitAmount: 800},
+		},
+	}
+
+	amount, err := paymentAmountForPrice(price, "usd", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), amount)
+
+	amount, err = paymentAmountForPrice(price, "EUR", 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2700), amount)
+
+	var validationErr *ValidationError
+	_, err = paymentAmountForPrice(price, "jpy", 1)
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "currency", validationErr.Field)
+
+	recurring := *price
+	recurring.Type = stripe.PriceTypeRecurring
+	_, err = paymentAmountForPrice(&recurring, "usd", 1)
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "price_id", validationErr.Field)
+
+	tiered := *price
+	tiered.BillingScheme = stripe.PriceBillingSchemeTiered
+	_, err = paymentAmountForPrice(&tiered, "usd", 1)
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "price_id", validationErr.Field)
+}
+
 func TestStripeService_ConfirmPaymentIntent(t *testing.T) {
 	cfg := &config.Config{
 		Stripe: config.StripeConfig{
@@ -202,6 +449,91 @@ func TestStripeService_ConfirmPaymentIntent(t *testing.T) {
 	assert.Nil(t, result, "Expected nil result on error")
 }
 
+func TestStripeService_PaymentIntentLifecycle(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+	ctx := context.Background()
+	amount := int64(1500)
+
+	// These will fail with the test key, but we're testing the methods exist and handle errors
+	result, err := service.GetPaymentIntent(ctx, "pi_test_123")
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, result, "Expected nil result on error")
+
+	result, err = service.UpdatePaymentIntent(ctx, "pi_test_123", &models.UpdatePaymentIntentRequest{Amount: &amount})
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, result, "Expected nil result on error")
+
+	result, err = service.CancelPaymentIntent(ctx, "pi_test_123", &models.CancelPaymentIntentRequest{CancellationReason: "duplicate"})
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, result, "Expected nil result on error")
+
+	result, err = service.CapturePaymentIntent(ctx, "pi_test_123", &models.CapturePaymentIntentRequest{AmountToCapture: 500})
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, result, "Expected nil result on error")
+}
+
+func TestStripeService_ListPaymentIntents(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+	ctx := context.Background()
+
+	_, err := service.ListPaymentIntents(ctx, &models.ListPaymentIntentsRequest{CustomerID: "cus_123"})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to list payment intents")
+
+	// Filtering by status goes through the search API
+	_, err = service.ListPaymentIntents(ctx, &models.ListPaymentIntentsRequest{Status: "succeeded"})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to search payment intents")
+}
+
+func TestValidateCaptureAmount(t *testing.T) {
+	authorized := &models.PaymentIntent{
+		ID:               "pi_123",
+		Status:           "requires_capture",
+		Amount:           5000,
+		AmountCapturable: 5000,
+	}
+
+	assert.NoError(t, validateCaptureAmount(authorized, 2500), "Expected partial capture to be allowed")
+	assert.NoError(t, validateCaptureAmount(authorized, 5000), "Expected full capture to be allowed")
+
+	err := validateCaptureAmount(authorized, 5001)
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "amount_to_capture", validationErr.Field)
+
+	err = validateCaptureAmount(&models.PaymentIntent{Status: "succeeded"}, 100)
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "payment_intent", validationErr.Field)
+}
+
+func TestBuildPaymentIntentSearchQuery(t *testing.T) {
+	query := buildPaymentIntentSearchQuery(&models.ListPaymentIntentsRequest{
+		Status:     "requires_capture",
+		CustomerID: "cus_123",
+		CreatedGte: 1640995200,
+		CreatedLte: 1672531200,
+	})
+
+	assert.Equal(t, "status:'requires_capture' AND customer:'cus_123' AND created>=1640995200 AND created<=1672531200", query)
+}
+
+func TestPageLimit(t *testing.T) {
+	assert.Equal(t, int64(DefaultListLimit), pageLimit(0), "Expected default limit when unset")
+	assert.Equal(t, int64(40), pageLimit(40))
+	assert.Equal(t, int64(MaxListLimit), pageLimit(MaxListLimit+1), "Expected limit to be capped")
+}
+
 func TestStripeService_CreateProduct(t *testing.T) {
 	cfg := &config.Config{
 		Stripe: config.StripeConfig{
@@ -224,6 +556,43 @@ func TestStripeService_CreateProduct(t *testing.T) {
 	assert.Nil(t, result, "Expected nil result on error")
 }
 
+func TestStripeService_ProductLifecycle(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+	ctx := context.Background()
+
+	// These will fail with the test key, but we're testing the methods exist and handle errors
+	product, err := service.GetProduct(ctx, "prod_test_123")
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, product, "Expected nil result on error")
+
+	name := "Pro plan"
+	images := []string{}
+	product, err = service.UpdateProduct(ctx, "prod_test_123", &models.UpdateProductRequest{Name: &name, Images: &images})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to update product")
+	assert.Nil(t, product, "Expected nil result on error")
+
+	product, err = service.ArchiveProduct(ctx, "prod_test_123")
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to archive product")
+	assert.Nil(t, product, "Expected nil result on error")
+
+	deleted, err := service.DeleteProduct(ctx, "prod_test_123")
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to delete product")
+	assert.Nil(t, deleted, "Expected nil result on error")
+
+	active := false
+	_, err = service.ListProducts(ctx, &models.ListProductsRequest{Active: &active})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to list products")
+}
+
 func TestStripeService_CreatePrice(t *testing.T) {
 	cfg := &config.Config{
 		Stripe: config.StripeConfig{
@@ -250,6 +619,274 @@ func TestStripeService_CreatePrice(t *testing.T) {
 	assert.Nil(t, result, "Expected nil result on error")
 }
 
+func TestValidateRecurringPrice(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   models.CreatePriceRequest
+		wantField string
+	}{
+		{
+			name:    "one-time price",
+			request: models.CreatePriceRequest{Type: "one_time"},
+		},
+		{
+			name:    "monthly price",
+			request: models.CreatePriceRequest{Type: "recurring", RecurringInterval: "month"},
+		},
+		{
+			name:    "quarterly metered price",
+			request: models.CreatePriceRequest{Type: "recurring", RecurringInterval: "month", IntervalCount: 3, UsageType: "metered", AggregateUsage: "max"},
+		},
+		{
+			name:      "recurring price without interval",
+			request:   models.CreatePriceRequest{Type: "recurring"},
+			wantField: "recurring_interval",
+		},
+		{
+			name:      "interval longer than three years",
+			request:   models.CreatePriceRequest{Type: "recurring", RecurringInterval: "week", IntervalCount: 157},
+			wantField: "interval_count",
+		},
+		{
+			name:      "aggregate usage on licensed price",
+			request:   models.CreatePriceRequest{Type: "recurring", RecurringInterval: "month", AggregateUsage: "sum"},
+			wantField: "aggregate_usage",
+		},
+		{
+			name:      "recurring options on one-time price",
+			request:   models.CreatePriceRequest{Type: "one_time", TrialPeriodDays: 7},
+			wantField: "type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRecurringPrice(&tt.request)
+			if tt.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.wantField, validationErr.Field)
+		})
+	}
+}
+
+func TestValidatePriceCurrencies(t *testing.T) {
+	assert.NoError(t, validatePriceCurrencies(&models.CreatePriceRequest{
+		Currency:        "usd",
+		CurrencyOptions: map[string]models.CurrencyOption{"eur": {UnitAmount: 900}, "GBP": {UnitAmount: 800}},
+	}))
+
+	tests := []struct {
+		name      string
+		request   models.CreatePriceRequest
+		wantField string
+	}{
+		{
+			name:      "unknown price currency",
+			request:   models.CreatePriceRequest{Currency: "abc"},
+			wantField: "currency",
+		},
+		{
+			name:      "unknown option currency",
+			request:   models.CreatePriceRequest{Currency: "usd", CurrencyOptions: map[string]models.CurrencyOption{"xyz": {UnitAmount: 900}}},
+			wantField: "currency_options",
+		},
+		{
+			name:      "option repeats price currency",
+			request:   models.CreatePriceRequest{Currency: "usd", CurrencyOptions: map[string]models.CurrencyOption{"USD": {UnitAmount: 900}}},
+			wantField: "currency_options",
+		},
+		{
+			name:      "options on tiered price",
+			request:   models.CreatePriceRequest{Currency: "usd", BillingScheme: "tiered", CurrencyOptions: map[string]models.CurrencyOption{"eur": {UnitAmount: 900}}},
+			wantField: "currency_options",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErr *ValidationError
+			require.ErrorAs(t, validatePriceCurrencies(&tt.request), &validationErr)
+			assert.Equal(t, tt.wantField, validationErr.Field)
+		})
+	}
+}
+
+func TestValidateTieredPrice(t *testing.T) {
+	amount := func(v int64) *int64 { return &v }
+	tiered := func(tiers ...models.PriceTier) models.CreatePriceRequest {
+		return models.CreatePriceRequest{
+			Type:              "recurring",
+			RecurringInterval: "month",
+			BillingScheme:     "tiered",
+			TiersMode:         "graduated",
+			Tiers:             tiers,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		request   models.CreatePriceRequest
+		wantField string
+	}{
+		{
+			name:    "per-unit price",
+			request: models.CreatePriceRequest{Type: "one_time", UnitAmount: 100},
+		},
+		{
+			name: "graduated tiers",
+			request: tiered(
+				models.PriceTier{UpTo: 1000, UnitAmount: amount(0)},
+				models.PriceTier{UpTo: 10000, UnitAmount: amount(2)},
+				models.PriceTier{UpTo: models.TierUpToInf, UnitAmount: amount(1), FlatAmount: amount(500)},
+			),
+		},
+		{
+			name:      "tiers on per-unit price",
+			request:   models.CreatePriceRequest{Type: "recurring", RecurringInterval: "month", UnitAmount: 100, Tiers: []models.PriceTier{{UpTo: models.TierUpToInf, UnitAmount: amount(1)}}},
+			wantField: "billing_scheme",
+		},
+		{
+			name:      "tiered one-time price",
+			request:   models.CreatePriceRequest{Type: "one_time", BillingScheme: "tiered", TiersMode: "volume"},
+			wantField: "billing_scheme",
+		},
+		{
+			name:      "tiered price without tiers",
+			request:   tiered(),
+			wantField: "tiers",
+		},
+		{
+			name:      "final tier is bounded",
+			request:   tiered(models.PriceTier{UpTo: 1000, UnitAmount: amount(5)}),
+			wantField: "tiers",
+		},
+		{
+			name: "unbounded tier before the last",
+			request: tiered(
+				models.PriceTier{UpTo: models.TierUpToInf, UnitAmount: amount(5)},
+				models.PriceTier{UpTo: models.TierUpToInf, UnitAmount: amount(1)},
+			),
+			wantField: "tiers",
+		},
+		{
+			name: "tiers out of order",
+			request: tiered(
+				models.PriceTier{UpTo: 1000, UnitAmount: amount(5)},
+				models.PriceTier{UpTo: 500, UnitAmount: amount(3)},
+				models.PriceTier{UpTo: models.TierUpToInf, UnitAmount: amount(1)},
+			),
+			wantField: "tiers",
+		},
+		{
+			name:      "tier without amount",
+			request:   tiered(models.PriceTier{UpTo: models.TierUpToInf}),
+			wantField: "tiers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTieredPrice(&tt.request)
+			if tt.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.wantField, validationErr.Field)
+		})
+	}
+
+	withUnitAmount := tiered(models.PriceTier{UpTo: models.TierUpToInf, UnitAmount: amount(1)})
+	withUnitAmount.UnitAmount = 100
+	var validationErr *ValidationError
+	require.ErrorAs(t, validateTieredPrice(&withUnitAmount), &validationErr)
+	assert.Equal(t, "unit_amount", validationErr.Field)
+
+	withoutMode := tiered(models.PriceTier{UpTo: models.TierUpToInf, UnitAmount: amount(1)})
+	withoutMode.TiersMode = ""
+	require.ErrorAs(t, validateTieredPrice(&withoutMode), &validationErr)
+	assert.Equal(t, "tiers_mode", validationErr.Field)
+
+	withTransform := tiered(models.PriceTier{UpTo: models.TierUpToInf, UnitAmount: amount(1)})
+	withTransform.TransformQuantity = &models.TransformQuantity{DivideBy: 1000, Round: "up"}
+	require.ErrorAs(t, validateTieredPrice(&withTransform), &validationErr)
+	assert.Equal(t, "transform_quantity", validationErr.Field)
+}
+
+func TestBuildPriceTierParams(t *testing.T) {
+	unitAmount := int64(2)
+	flatAmount := int64(500)
+
+	params := buildPriceTierParams([]models.PriceTier{
+		{UpTo: 1000, UnitAmount: &unitAmount},
+		{UpTo: models.TierUpToInf, FlatAmount: &flatAmount},
+	})
+
+	require.Len(t, params, 2)
+	assert.Equal(t, int64(1000), *params[0].UpTo)
+	assert.Nil(t, params[0].UpToInf)
+	assert.Equal(t, int64(2), *params[0].UnitAmount)
+	assert.Nil(t, params[1].UpTo)
+	assert.True(t, *params[1].UpToInf)
+	assert.Equal(t, int64(500), *params[1].FlatAmount)
+}
+
+func TestBuildPriceRecurringParams(t *testing.T) {
+	params := buildPriceRecurringParams(&models.CreatePriceRequest{
+		Type:              "recurring",
+		RecurringInterval: "month",
+		IntervalCount:     3,
+		UsageType:         "metered",
+		AggregateUsage:    "sum",
+		TrialPeriodDays:   14,
+	})
+
+	assert.Equal(t, "month", *params.Interval)
+	assert.Equal(t, int64(3), *params.IntervalCount)
+	assert.Equal(t, "metered", *params.UsageType)
+	assert.Equal(t, "sum", *params.AggregateUsage)
+	assert.Equal(t, int64(14), *params.TrialPeriodDays)
+
+	minimal := buildPriceRecurringParams(&models.CreatePriceRequest{Type: "recurring", RecurringInterval: "year"})
+	assert.Nil(t, minimal.IntervalCount)
+	assert.Nil(t, minimal.UsageType)
+	assert.Nil(t, minimal.AggregateUsage)
+	assert.Nil(t, minimal.TrialPeriodDays)
+}
+
+func TestStripeService_PriceManagement(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+	ctx := context.Background()
+
+	// These will fail with the test key, but we're testing the methods exist and handle errors
+	price, err := service.GetPrice(ctx, "price_test_123")
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, price, "Expected nil result on error")
+
+	lookupKey := "pro_monthly"
+	price, err = service.UpdatePrice(ctx, "price_test_123", &models.UpdatePriceRequest{LookupKey: &lookupKey, TransferLookupKey: true})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to update price")
+	assert.Nil(t, price, "Expected nil result on error")
+
+	prices, err := service.ListPrices(ctx, &models.ListPricesRequest{LookupKeys: []string{"pro_monthly", "pro_yearly"}})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to list prices")
+	assert.Nil(t, prices, "Expected nil result on error")
+}
+
 func TestStripeService_CreateSubscription(t *testing.T) {
 	cfg := &config.Config{
 		Stripe: config.StripeConfig{
@@ -283,11 +920,331 @@ func TestStripeService_CancelSubscription(t *testing.T) {
 	ctx := context.Background()
 
 	// This will fail with the test key, but we're testing the method exists and handles errors
-	result, err := service.CancelSubscription(ctx, "sub_test_123")
+	result, err := service.CancelSubscription(ctx, "sub_test_123", &models.CancelSubscriptionRequest{})
 
 	// Should return an error due to invalid test key
 	assert.Error(t, err, "Expected error with test key")
 	assert.Nil(t, result, "Expected nil result on error")
+
+	result, err = service.CancelSubscription(ctx, "sub_test_123", &models.CancelSubscriptionRequest{Mode: "at_period_end"})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to schedule subscription cancellation")
+	assert.Nil(t, result, "Expected nil result on error")
+
+	result, err = service.UndoSubscriptionCancellation(ctx, "sub_test_123")
+	require.Error(t, err, "Expected error with test key")
+	assert.Nil(t, result, "Expected nil result on error")
+}
+
+func TestValidateSubscriptionCancellation(t *testing.T) {
+	yes := true
+	future := time.Now().Add(24 * time.Hour).Unix()
+
+	tests := []struct {
+		name      string
+		request   models.CancelSubscriptionRequest
+		wantField string
+	}{
+		{
+			name:    "default immediate cancellation",
+			request: models.CancelSubscriptionRequest{},
+		},
+		{
+			name:    "immediate cancellation with proration and final invoice",
+			request: models.CancelSubscriptionRequest{Mode: "immediately", Prorate: &yes, InvoiceNow: &yes},
+		},
+		{
+			name:    "cancel at period end",
+			request: models.CancelSubscriptionRequest{Mode: "at_period_end"},
+		},
+		{
+			name:    "cancel at timestamp with proration",
+			request: models.CancelSubscriptionRequest{Mode: "at_timestamp", CancelAt: future, Prorate: &yes},
+		},
+		{
+			name:      "cancel at timestamp in the past",
+			request:   models.CancelSubscriptionRequest{Mode: "at_timestamp", CancelAt: time.Now().Add(-time.Hour).Unix()},
+			wantField: "cancel_at",
+		},
+		{
+			name:      "cancel_at without at_timestamp mode",
+			request:   models.CancelSubscriptionRequest{Mode: "at_period_end", CancelAt: future},
+			wantField: "cancel_at",
+		},
+		{
+			name:      "invoice now with scheduled cancellation",
+			request:   models.CancelSubscriptionRequest{Mode: "at_timestamp", CancelAt: future, InvoiceNow: &yes},
+			wantField: "invoice_now",
+		},
+		{
+			name:      "prorate at period end",
+			request:   models.CancelSubscriptionRequest{Mode: "at_period_end", Prorate: &yes},
+			wantField: "prorate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSubscriptionCancellation(&tt.request)
+			if tt.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.wantField, validationErr.Field)
+		})
+	}
+}
+
+func TestStripeService_SubscriptionLifecycle(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+	ctx := context.Background()
+
+	// These will fail with the test key, but we're testing the methods exist and handle errors
+	subscription, err := service.GetSubscription(ctx, "sub_test_123")
+	assert.Error(t, err, "Expected error with test key")
+	assert.Nil(t, subscription, "Expected nil result on error")
+
+	paymentMethodID := "pm_test_123"
+	subscription, err = service.UpdateSubscription(ctx, "sub_test_123", &models.UpdateSubscriptionRequest{DefaultPaymentMethodID: &paymentMethodID})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to update subscription")
+	assert.Nil(t, subscription, "Expected nil result on error")
+
+	subscription, err = service.PauseSubscription(ctx, "sub_test_123", &models.PauseSubscriptionRequest{})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to pause subscription")
+	assert.Nil(t, subscription, "Expected nil result on error")
+
+	subscription, err = service.ResumeSubscription(ctx, "sub_test_123")
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to resume subscription")
+	assert.Nil(t, subscription, "Expected nil result on error")
+
+	subscriptions, err := service.ListSubscriptions(ctx, &models.ListSubscriptionsRequest{CustomerID: "cus_test_123", Status: "all"})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to list subscriptions")
+	assert.Nil(t, subscriptions, "Expected nil result on error")
+}
+
+func TestStripeService_ChangeSubscriptionPlan(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+	ctx := context.Background()
+	req := &models.ChangePlanRequest{PriceID: "price_test_pro", ProrationBehavior: "always_invoice"}
+
+	// These will fail with the test key, but we're testing the methods exist and handle errors
+	subscription, err := service.ChangeSubscriptionPlan(ctx, "sub_test_123", req)
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to get subscription")
+	assert.Nil(t, subscription, "Expected nil result on error")
+
+	preview, err := service.PreviewSubscriptionPlanChange(ctx, "sub_test_123", req)
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to get subscription")
+	assert.Nil(t, preview, "Expected nil result on error")
+}
+
+func TestStripeService_SubscriptionItems(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+	ctx := context.Background()
+
+	// These will fail with the test key, but we're testing the methods exist and handle errors
+	item, err := service.AddSubscriptionItem(ctx, "sub_test_123", &models.AddSubscriptionItemRequest{PriceID: "price_test_seat", Quantity: 3})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to add subscription item")
+	assert.Nil(t, item, "Expected nil result on error")
+
+	quantity := int64(5)
+	item, err = service.UpdateSubscriptionItem(ctx, "sub_test_123", "si_test_123", &models.UpdateSubscriptionItemRequest{Quantity: &quantity})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to get subscription item")
+	assert.Nil(t, item, "Expected nil result on error")
+
+	deleted, err := service.RemoveSubscriptionItem(ctx, "sub_test_123", "si_test_123", &models.RemoveSubscriptionItemRequest{})
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to get subscription item")
+	assert.Nil(t, deleted, "Expected nil result on error")
+}
+
+func TestValidateSubscriptionItems(t *testing.T) {
+	assert.NoError(t, validateSubscriptionItems(&models.CreateSubscriptionRequest{PriceID: "price_123"}))
+	assert.NoError(t, validateSubscriptionItems(&models.CreateSubscriptionRequest{
+		Items: []models.SubscriptionItemRequest{{PriceID: "price_base"}, {PriceID: "price_seat"}},
+	}))
+
+	err := validateSubscriptionItems(&models.CreateSubscriptionRequest{
+		Items: []models.SubscriptionItemRequest{{PriceID: "price_seat"}, {PriceID: "price_seat"}},
+	})
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "items", validationErr.Field)
+}
+
+func TestBuildSubscriptionItemsParams(t *testing.T) {
+	single := buildSubscriptionItemsParams(&models.CreateSubscriptionRequest{PriceID: "price_123", Quantity: 4})
+	require.Len(t, single, 1)
+	assert.Equal(t, "price_123", *single[0].Price)
+	assert.Equal(t, int64(4), *single[0].Quantity)
+
+	defaultQuantity := buildSubscriptionItemsParams(&models.CreateSubscriptionRequest{PriceID: "price_123"})
+	require.Len(t, defaultQuantity, 1)
+	assert.Nil(t, defaultQuantity[0].Quantity)
+
+	multiple := buildSubscriptionItemsParams(&models.CreateSubscriptionRequest{
+		Items: []models.SubscriptionItemRequest{
+			{PriceID: "price_base"},
+			{PriceID: "price_seat", Quantity: 10, Metadata: map[string]string{"addon": "seats"}},
+		},
+	})
+	require.Len(t, multiple, 2)
+	assert.Equal(t, "price_base", *multiple[0].Price)
+	assert.Nil(t, multiple[0].Quantity)
+	assert.Equal(t, "price_seat", *multiple[1].Price)
+	assert.Equal(t, int64(10), *multiple[1].Quantity)
+	assert.Equal(t, "seats", multiple[1].Metadata["addon"])
+}
+
+func TestStripeService_EndSubscriptionTrial(t *testing.T) {
+	cfg := &config.Config{
+		Stripe: config.StripeConfig{
+			SecretKey: "sk_test_123",
+		},
+	}
+	service := NewStripeService(cfg)
+
+	// This will fail with the test key, but we're testing the method exists and handles errors
+	result, err := service.EndSubscriptionTrial(context.Background(), "sub_test_123")
+	require.Error(t, err, "Expected error with test key")
+	assert.Contains(t, err.Error(), "failed to get subscription")
+	assert.Nil(t, result, "Expected nil result on error")
+}
+
+func TestValidateSubscriptionTrial(t *testing.T) {
+	settings := &models.SubscriptionTrialSettings{
+		EndBehavior: models.SubscriptionTrialEndBehavior{MissingPaymentMethod: "cancel"},
+	}
+
+	tests := []struct {
+		name      string
+		request   models.CreateSubscriptionRequest
+		wantField string
+	}{
+		{
+			name:    "no trial",
+			request: models.CreateSubscriptionRequest{PriceID: "price_123"},
+		},
+		{
+			name:    "trial days with settings",
+			request: models.CreateSubscriptionRequest{PriceID: "price_123", TrialPeriodDays: 14, TrialSettings: settings},
+		},
+		{
+			name:    "trial end in the future",
+			request: models.CreateSubscriptionRequest{PriceID: "price_123", TrialEnd: time.Now().AddDate(0, 0, 14).Unix()},
+		},
+		{
+			name:      "trial end in the past",
+			request:   models.CreateSubscriptionRequest{PriceID: "price_123", TrialEnd: time.Now().Add(-time.Hour).Unix()},
+			wantField: "trial_end",
+		},
+		{
+			name:      "trial settings without a trial",
+			request:   models.CreateSubscriptionRequest{PriceID: "price_123", TrialSettings: settings},
+			wantField: "trial_settings",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSubscriptionTrial(&tt.request)
+			if tt.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.wantField, validationErr.Field)
+		})
+	}
+}
+
+func TestStripeService_PauseSubscription_ResumesAtInPast(t *testing.T) {
+	service := &StripeService{}
+
+	result, err := service.PauseSubscription(context.Background(), "sub_test_123", &models.PauseSubscriptionRequest{
+		ResumesAt: time.Now().Add(-time.Hour).Unix(),
+	})
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "resumes_at", validationErr.Field)
+	assert.Nil(t, result)
+}
+
+func TestValidateSubscriptionCollection(t *testing.T) {
+	sendInvoice := "send_invoice"
+	chargeAutomatically := "charge_automatically"
+	days := int64(30)
+
+	tests := []struct {
+		name      string
+		request   models.UpdateSubscriptionRequest
+		wantField string
+	}{
+		{
+			name:    "no collection change",
+			request: models.UpdateSubscriptionRequest{},
+		},
+		{
+			name:    "send invoice with days until due",
+			request: models.UpdateSubscriptionRequest{CollectionMethod: &sendInvoice, DaysUntilDue: &days},
+		},
+		{
+			name:    "charge automatically",
+			request: models.UpdateSubscriptionRequest{CollectionMethod: &chargeAutomatically},
+		},
+		{
+			name:      "send invoice without days until due",
+			request:   models.UpdateSubscriptionRequest{CollectionMethod: &sendInvoice},
+			wantField: "days_until_due",
+		},
+		{
+			name:      "charge automatically with days until due",
+			request:   models.UpdateSubscriptionRequest{CollectionMethod: &chargeAutomatically, DaysUntilDue: &days},
+			wantField: "days_until_due",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSubscriptionCollection(&tt.request)
+			if tt.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.wantField, validationErr.Field)
+		})
+	}
 }
 
 // Test converter functions with nil inputs
@@ -327,6 +1284,25 @@ func TestConvertStripeProduct_Nil(t *testing.T) {
 	assert.Nil(t, result, "Expected nil result for nil product")
 }
 
+func TestConvertStripeProduct(t *testing.T) {
+	service := &StripeService{}
+
+	result := service.convertStripeProduct(&stripe.Product{
+		ID:           "prod_123",
+		Name:         "Pro plan",
+		Images:       []string{"https://example.com/pro.png"},
+		DefaultPrice: &stripe.Price{ID: "price_123"},
+		Active:       true,
+		Created:      1640995200,
+		Updated:      1641081600,
+	})
+
+	assert.Equal(t, "prod_123", result.ID)
+	assert.Equal(t, []string{"https://example.com/pro.png"}, result.Images)
+	assert.Equal(t, "price_123", result.DefaultPriceID)
+	assert.Equal(t, time.Unix(1641081600, 0), result.UpdatedAt)
+}
+
 func TestConvertStripePrice_Nil(t *testing.T) {
 	cfg := &config.Config{
 		Stripe: config.StripeConfig{
@@ -339,6 +1315,91 @@ func TestConvertStripePrice_Nil(t *testing.T) {
 	assert.Nil(t, result, "Expected nil result for nil price")
 }
 
+func TestConvertStripePrice(t *testing.T) {
+	service := &StripeService{}
+
+	result := service.convertStripePrice(&stripe.Price{
+		ID:         "price_123",
+		Product:    &stripe.Product{ID: "prod_123"},
+		UnitAmount: 1500,
+		Currency:   stripe.CurrencyUSD,
+		Recurring: &stripe.PriceRecurring{
+			Interval:        stripe.PriceRecurringIntervalMonth,
+			IntervalCount:   3,
+			UsageType:       stripe.PriceRecurringUsageTypeMetered,
+			AggregateUsage:  stripe.PriceRecurringAggregateUsageSum,
+			TrialPeriodDays: 14,
+		},
+		CurrencyOptions: map[string]*stripe.PriceCurrencyOptions{
+			"usd": {UnitAmount: 1500},
+			"eur": {UnitAmount: 1400},
+		},
+		Nickname:  "Pro monthly",
+		LookupKey: "pro_monthly",
+		Active:    true,
+		Created:   1640995200,
+	})
+
+	assert.Equal(t, "prod_123", result.ProductID)
+	assert.Equal(t, "recurring", result.Type)
+	assert.Equal(t, "month", result.RecurringInterval)
+	assert.Equal(t, int64(3), result.IntervalCount)
+	assert.Equal(t, "metered", result.UsageType)
+	assert.Equal(t, "sum", result.AggregateUsage)
+	assert.Equal(t, int64(14), result.TrialPeriodDays)
+	assert.Equal(t, "Pro monthly", result.Nickname)
+	assert.Equal(t, "pro_monthly", result.LookupKey)
+	assert.Equal(t, map[string]models.CurrencyOption{"eur": {UnitAmount: 1400}}, result.CurrencyOptions)
+
+	tiered := service.convertStripePrice(&stripe.Price{
+		ID:            "price_456",
+		Product:       &stripe.Product{ID: "prod_123"},
+		Currency:      stripe.CurrencyUSD,
+		BillingScheme: stripe.PriceBillingSchemeTiered,
+		TiersMode:     stripe.PriceTiersModeVolume,
+		Tiers: []*stripe.PriceTier{
+			{UpTo: 1000, UnitAmount: 5},
+			{UpTo: 0, UnitAmount: 2, FlatAmount: 1000},
+		},
+	})
+
+	assert.Equal(t, "tiered", tiered.BillingScheme)
+	assert.Equal(t, "volume", tiered.TiersMode)
+	require.Len(t, tiered.Tiers, 2)
+	assert.Equal(t, models.TierUpTo(1000), tiered.Tiers[0].UpTo)
+	assert.Equal(t, int64(0), *tiered.Tiers[0].FlatAmount)
+	assert.Equal(t, models.TierUpToInf, tiered.Tiers[1].UpTo)
+	assert.Equal(t, int64(1000), *tiered.Tiers[1].FlatAmount)
+
+	free := service.convertStripePrice(&stripe.Price{
+		ID:            "price_free_tier",
+		Product:       &stripe.Product{ID: "prod_123"},
+		Currency:      stripe.CurrencyUSD,
+		BillingScheme: stripe.PriceBillingSchemeTiered,
+		TiersMode:     stripe.PriceTiersModeGraduated,
+		Tiers: []*stripe.PriceTier{
+			{UpTo: 1000, UnitAmount: 0},
+			{UpTo: 0, UnitAmount: 2},
+		},
+	})
+
+	require.Len(t, free.Tiers, 2)
+	require.NotNil(t, free.Tiers[0].UnitAmount, "Expected a zero unit amount to be kept")
+	assert.Equal(t, int64(0), *free.Tiers[0].UnitAmount)
+	require.NotNil(t, free.Tiers[0].FlatAmount)
+	assert.Equal(t, int64(0), *free.Tiers[0].FlatAmount)
+	assert.Equal(t, int64(2), *free.Tiers[1].UnitAmount)
+
+	transformed := service.convertStripePrice(&stripe.Price{
+		ID:                "price_789",
+		Product:           &stripe.Product{ID: "prod_123"},
+		TransformQuantity: &stripe.PriceTransformQuantity{DivideBy: 1000, Round: stripe.PriceTransformQuantityRoundUp},
+	})
+	require.NotNil(t, transformed.TransformQuantity)
+	assert.Equal(t, int64(1000), transformed.TransformQuantity.DivideBy)
+	assert.Equal(t, "up", transformed.TransformQuantity.Round)
+}
+
 func TestConvertStripeSubscription_Nil(t *testing.T) {
 	cfg := &config.Config{
 		Stripe: config.StripeConfig{
@@ -351,6 +1412,145 @@ func TestConvertStripeSubscription_Nil(t *testing.T) {
 	assert.Nil(t, result, "Expected nil result for nil subscription")
 }
 
+func TestConvertStripeSubscription(t *testing.T) {
+	service := &StripeService{}
+
+	result := service.convertStripeSubscription(&stripe.Subscription{
+		ID:       "sub_123",
+		Customer: &stripe.Customer{ID: "cus_123"},
+		Items: &stripe.SubscriptionItemList{
+			Data: []*stripe.SubscriptionItem{{Price: &stripe.Price{ID: "price_123"}}},
+		},
+		Status:            stripe.SubscriptionStatusTrialing,
+		CancelAtPeriodEnd: true,
+		CancelAt:          1700000000,
+		TrialStart:        1690000000,
+		TrialEnd:          1691209600,
+		CollectionMethod:  stripe.SubscriptionCollectionMethodSendInvoice,
+		DaysUntilDue:      30,
+		TrialSettings: &stripe.SubscriptionTrialSettings{
+			EndBehavior: &stripe.SubscriptionTrialSettingsEndBehavior{
+				MissingPaymentMethod: stripe.SubscriptionTrialSettingsEndBehaviorMissingPaymentMethodPause,
+			},
+		},
+		DefaultPaymentMethod: &stripe.PaymentMethod{ID: "pm_123"},
+		PauseCollection: &stripe.SubscriptionPauseCollection{
+			Behavior:  stripe.SubscriptionPauseCollectionBehaviorKeepAsDraft,
+			ResumesAt: 1695000000,
+		},
+		Created: 1690000000,
+	})
+
+	require.NotNil(t, result)
+	assert.Equal(t, "price_123", result.PriceID)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, "price_123", result.Items[0].PriceID)
+	assert.True(t, result.CancelAtPeriodEnd)
+	assert.Equal(t, time.Unix(1700000000, 0), *result.CancelAt)
+	assert.Nil(t, result.CanceledAt)
+	assert.Equal(t, time.Unix(1690000000, 0), *result.TrialStart)
+	assert.Equal(t, time.Unix(1691209600, 0), *result.TrialEnd)
+	require.NotNil(t, result.TrialSettings)
+	assert.Equal(t, "pause", result.TrialSettings.EndBehavior.MissingPaymentMethod)
+	assert.Equal(t, "send_invoice", result.CollectionMethod)
+	assert.Equal(t, int64(30), result.DaysUntilDue)
+	assert.Equal(t, "pm_123", result.DefaultPaymentMethodID)
+	require.NotNil(t, result.PauseCollection)
+	assert.Equal(t, "keep_as_draft", result.PauseCollection.Behavior)
+	assert.Equal(t, time.Unix(1695000000, 0), *result.PauseCollection.ResumesAt)
+}
+
+func TestConvertStripeSubscription_Items(t *testing.T) {
+	service := &StripeService{}
+
+	result := service.convertStripeSubscription(&stripe.Subscription{
+		ID:       "sub_123",
+		Customer: &stripe.Customer{ID: "cus_123"},
+		Items: &stripe.SubscriptionItemList{
+			Data: []*stripe.SubscriptionItem{
+				{ID: "si_base", Price: &stripe.Price{ID: "price_base"}, Quantity: 1, Created: 1690000000},
+				{ID: "si_seat", Price: &stripe.Price{ID: "price_seat"}, Quantity: 12, Metadata: map[string]string{"addon": "seats"}},
+			},
+		},
+	})
+
+	require.NotNil(t, result)
+	assert.Equal(t, "price_base", result.PriceID)
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, models.SubscriptionItem{
+		ID:        "si_base",
+		PriceID:   "price_base",
+		Quantity:  1,
+		CreatedAt: time.Unix(1690000000, 0),
+	}, result.Items[0])
+	assert.Equal(t, int64(12), result.Items[1].Quantity)
+	assert.Equal(t, "seats", result.Items[1].Metadata["addon"])
+
+	// An empty item list must not panic
+	empty := service.convertStripeSubscription(&stripe.Subscription{
+		ID:    "sub_456",
+		Items: &stripe.SubscriptionItemList{},
+	})
+	require.NotNil(t, empty)
+	assert.Empty(t, empty.PriceID)
+	assert.Empty(t, empty.Items)
+
+	noItems := service.convertStripeSubscription(&stripe.Subscription{ID: "sub_789"})
+	require.NotNil(t, noItems)
+	assert.Empty(t, noItems.Items)
+	assert.Empty(t, noItems.CustomerID)
+}
+
+func TestConvertPlanChangePreview(t *testing.T) {
+	service := &StripeService{}
+
+	assert.Nil(t, service.convertPlanChangePreview(nil))
+
+	result := service.convertPlanChangePreview(&stripe.Invoice{
+		Currency:  stripe.CurrencyUSD,
+		AmountDue: 3500,
+		Lines: &stripe.InvoiceLineItemList{
+			Data: []*stripe.InvoiceLineItem{
+				{
+					Description: "Unused time on Basic",
+					Amount:      -500,
+					Currency:    stripe.CurrencyUSD,
+					Price:       &stripe.Price{ID: "price_basic"},
+					Quantity:    1,
+					Proration:   true,
+					Period:      &stripe.Period{Start: 1700000000, End: 1701000000},
+				},
+				{
+					Description: "Remaining time on Pro",
+					Amount:      1500,
+					Currency:    stripe.CurrencyUSD,
+					Price:       &stripe.Price{ID: "price_pro"},
+					Quantity:    1,
+					Proration:   true,
+					Period:      &stripe.Period{Start: 1700000000, End: 1701000000},
+				},
+				{
+					Description: "1 × Pro",
+					Amount:      2500,
+					Currency:    stripe.CurrencyUSD,
+					Price:       &stripe.Price{ID: "price_pro"},
+					Quantity:    1,
+				},
+			},
+		},
+	})
+
+	require.NotNil(t, result)
+	assert.Equal(t, "usd", result.Currency)
+	assert.Equal(t, int64(3500), result.AmountDue)
+	assert.Equal(t, int64(1000), result.ProrationAmount)
+	require.Len(t, result.Lines, 2)
+	assert.Equal(t, "price_basic", result.Lines[0].PriceID)
+	assert.Equal(t, int64(-500), result.Lines[0].Amount)
+	assert.Equal(t, time.Unix(1700000000, 0), result.Lines[0].PeriodStart)
+	assert.Equal(t, time.Unix(1701000000, 0), result.Lines[1].PeriodEnd)
+}
+
 // Test the adapter methods
 func TestStripeCustomerAdapter(t *testing.T) {
 	// Test with nil customer
@@ -362,7 +1562,16 @@ func TestStripeCustomerAdapter(t *testing.T) {
 	assert.Equal(t, "", adapter.GetPhone())
 	assert.Equal(t, "", adapter.GetDescription())
 	assert.Nil(t, adapter.GetMetadata())
+	assert.Equal(t, "", adapter.GetDefaultPaymentMethodID())
 	assert.Equal(t, int64(0), adapter.GetCreated())
+
+	// Test default payment method from invoice settings
+	adapter = &stripeCustomerAdapter{customer: &stripe.Customer{
+		InvoiceSettings: &stripe.CustomerInvoiceSettings{
+			DefaultPaymentMethod: &stripe.PaymentMethod{ID: "pm_123"},
+		},
+	}}
+	assert.Equal(t, "pm_123", adapter.GetDefaultPaymentMethodID())
 }
 
 // Test converter functions with mock data
@@ -396,3 +1605,12 @@ func TestConvertStripeCustomerInterface_WithMockData(t *testing.T) {
 	assert.Equal(t, time.Unix(1640995200, 0), result.CreatedAt)
 	assert.Equal(t, time.Unix(1640995200, 0), result.UpdatedAt)
 }
+
+func TestAddMetadataUpdates(t *testing.T) {
+	params := &stripe.CustomerParams{}
+	addMetadataUpdates(params, nil)
+	assert.Nil(t, params.Metadata, "Expected no metadata to be sent when none is given")
+
+	addMetadataUpdates(params, map[string]string{"plan": "pro", "legacy_id": ""})
+	assert.Equal(t, map[string]string{"plan": "pro", "legacy_id": ""}, params.Metadata)
+}
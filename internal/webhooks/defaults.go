@@ -0,0 +1,73 @@
+package webhooks
+
+import (
+	"context"
+	"log"
+
+	"github.com/stripe/stripe-go/v76"
+)
+
+// RegisterLoggingHandlers registers handlers that log the payment, dispute, subscription
+// and invoice events the service cares about
+func RegisterLoggingHandlers(d *Dispatcher) {
+	for _, eventType := range []stripe.EventType{
+		stripe.EventTypePaymentIntentSucceeded,
+		stripe.EventTypePaymentIntentPaymentFailed,
+		stripe.EventTypePaymentIntentCanceled,
+	} {
+		d.Register(eventType, ObjectHandler(logPaymentIntentEvent))
+	}
+
+	for _, eventType := range []stripe.EventType{
+		stripe.EventTypeChargeDisputeCreated,
+		stripe.EventTypeChargeDisputeUpdated,
+		stripe.EventTypeChargeDisputeClosed,
+		stripe.EventTypeChargeDisputeFundsWithdrawn,
+		stripe.EventTypeChargeDisputeFundsReinstated,
+	} {
+		d.Register(eventType, ObjectHandler(logDisputeEvent))
+	}
+
+	for _, eventType := range []stripe.EventType{
+		stripe.EventTypeCustomerSubscriptionCreated,
+		stripe.EventTypeCustomerSubscriptionUpdated,
+		stripe.EventTypeCustomerSubscriptionDeleted,
+	} {
+		d.Register(eventType, ObjectHandler(logSubscriptionEvent))
+	}
+
+	for _, eventType := range []stripe.EventType{
+		stripe.EventTypeInvoicePaymentSucceeded,
+		stripe.EventTypeInvoicePaymentFailed,
+	} {
+		d.Register(eventType, ObjectHandler(logInvoiceEvent))
+	}
+}
+
+func logPaymentIntentEvent(ctx context.Context, event stripe.Event, paymentIntent *stripe.PaymentIntent) error {
+	log.Printf("Webhook event - Type: %s, PaymentIntent: %s, Status: %s, Amount: %d %s",
+		event.Type, paymentIntent.ID, paymentIntent.Status, paymentIntent.Amount, paymentIntent.Currency)
+	return nil
+}
+
+func logDisputeEvent(ctx context.Context, event stripe.Event, dispute *stripe.Dispute) error {
+	var dueBy int64
+	if dispute.EvidenceDetails != nil {
+		dueBy = dispute.EvidenceDetails.DueBy
+	}
+	log.Printf("Webhook event - Type: %s, Dispute: %s, Status: %s, Reason: %s, Amount: %d %s, EvidenceDueBy: %d",
+		event.Type, dispute.ID, dispute.Status, dispute.Reason, dispute.Amount, dispute.Currency, dueBy)
+	return nil
+}
+
+func logSubscriptionEvent(ctx context.Context, event stripe.Event, subscription *stripe.Subscription) error {
+	log.Printf("Webhook event - Type: %s, Subscription: %s, Status: %s",
+		event.Type, subscription.ID, subscription.Status)
+	return nil
+}
+
+func logInvoiceEvent(ctx context.Context, event stripe.Event, invoice *stripe.Invoice) error {
+	log.Printf("Webhook event - Type: %s, Invoice: %s, Status: %s, AmountDue: %d %s",
+		event.Type, invoice.ID, invoice.Status, invoice.AmountDue, invoice.Currency)
+	return nil
+}
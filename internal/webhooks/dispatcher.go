@@ -0,0 +1,178 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/stripe/stripe-go/v76"
+)
+
+// HandlerFunc processes a single Stripe event
+type HandlerFunc func(ctx context.Context, event stripe.Event) error
+
+// Dispatcher routes Stripe events to the handlers registered for their event type
+type Dispatcher struct {
+	mu        sync.RWMutex
+	handlers  map[stripe.EventType][]HandlerFunc
+	unhandled HandlerFunc
+}
+
+// NewDispatcher creates a dispatcher with no registered handlers.
+// Events without handlers are logged and acknowledged until OnUnhandled is set.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		handlers:  make(map[stripe.EventType][]HandlerFunc),
+		unhandled: logUnhandledEvent,
+	}
+}
+
+// Register adds a handler for the given event type. Multiple handlers may be
+// registered for the same type; they run in registration order.
+func (d *Dispatcher) Register(eventType stripe.EventType, handler HandlerFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.handlers[eventType] = append(d.handlers[eventType], handler)
+}
+
+// OnUnhandled sets the hook invoked for events that have no registered handlers
+func (d *Dispatcher) OnUnhandled(handler HandlerFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.unhandled = handler
+}
+
+// HasHandlers reports whether any handler is registered for the event type
+func (d *Dispatcher) HasHandlers(eventType stripe.EventType) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return len(d.handlers[eventType]) > 0
+}
+
+// HandlerError reports the failure of a single registered handler. Index is the
+// handler's position in registration order for the event type.
+type HandlerError struct {
+	Index     int
+	EventType stripe.EventType
+	Err       error
+}
+
+func (e *HandlerError) Error() string {
+	return fmt.Sprintf("handler %d for %s: %v", e.Index, e.EventType, e.Err)
+}
+
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
+// Dispatch runs every handler registered for the event's type. A failing or
+// panicking handler does not prevent the remaining handlers from running; all
+// failures are joined into the returned error as *HandlerError values.
+func (d *Dispatcher) Dispatch(ctx context.Context, event stripe.Event) error {
+	return d.DispatchHandlers(ctx, event, nil)
+}
+
+// DispatchHandlers runs only the handlers at the given registration indexes,
+// such as those reported by FailedHandlers for an earlier attempt. A nil or
+// empty list runs every handler, like Dispatch. Indexes that are no longer
+// registered are skipped.
+func (d *Dispatcher) DispatchHandlers(ctx context.Context, event stripe.Event, indexes []int) error {
+	d.mu.RLock()
+	handlers := append([]HandlerFunc(nil), d.handlers[event.Type]...)
+	unhandled := d.unhandled
+	d.mu.RUnlock()
+
+	if len(handlers) == 0 {
+		if unhandled == nil {
+			return nil
+		}
+		return runHandler(ctx, unhandled, event)
+	}
+
+	if len(indexes) == 0 {
+		indexes = make([]int, len(handlers))
+		for i := range handlers {
+			indexes[i] = i
+		}
+	}
+
+	var errs []error
+	for _, i := range indexes {
+		if i < 0 || i >= len(handlers) {
+			log.Printf("Webhook handler no longer registered - EventID: %s, Type: %s, Handler: %d", event.ID, event.Type, i)
+			continue
+		}
+		if err := runHandler(ctx, handlers[i], event); err != nil {
+			errs = append(errs, &HandlerError{Index: i, EventType: event.Type, Err: err})
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// FailedHandlers returns the registration indexes of the handlers that failed
+// in an error returned by Dispatch or DispatchHandlers. It returns nil when the
+// error does not identify individual handlers, for example when the unhandled
+// hook failed.
+func FailedHandlers(err error) []int {
+	var handlerErrs []error
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		handlerErrs = joined.Unwrap()
+	} else if err != nil {
+		handlerErrs = []error{err}
+	}
+
+	var indexes []int
+	for _, handlerErr := range handlerErrs {
+		var target *HandlerError
+		if errors.As(handlerErr, &target) {
+			indexes = append(indexes, target.Index)
+		}
+	}
+	return indexes
+}
+
+// runHandler invokes a handler, converting a panic into an error
+func runHandler(ctx context.Context, handler HandlerFunc, event stripe.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, event)
+}
+
+// ObjectHandler adapts a handler for a typed Stripe object (for example
+// *stripe.PaymentIntent) into a HandlerFunc that decodes the event payload
+func ObjectHandler[T any](handler func(ctx context.Context, event stripe.Event, object *T) error) HandlerFunc {
+	return func(ctx context.Context, event stripe.Event) error {
+		var object T
+		if err := DecodeEventObject(event, &object); err != nil {
+			return err
+		}
+		return handler(ctx, event, &object)
+	}
+}
+
+// DecodeEventObject unmarshals the raw event object into the given Stripe type
+func DecodeEventObject(event stripe.Event, target interface{}) error {
+	if event.Data == nil {
+		return fmt.Errorf("event %s has no data", event.ID)
+	}
+	if err := json.Unmarshal(event.Data.Raw, target); err != nil {
+		return fmt.Errorf("failed to decode %s event object: %w", event.Type, err)
+	}
+	return nil
+}
+
+func logUnhandledEvent(ctx context.Context, event stripe.Event) error {
+	log.Printf("Webhook event unhandled - EventID: %s, Type: %s", event.ID, event.Type)
+	return nil
+}
@@ -0,0 +1,171 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v76"
+)
+
+func newTestEvent(eventType stripe.EventType, object map[string]interface{}) stripe.Event {
+	raw, _ := json.Marshal(object)
+	return stripe.Event{
+		ID:   "evt_test123",
+		Type: eventType,
+		Data: &stripe.EventData{Raw: raw},
+	}
+}
+
+func TestNewDispatcher(t *testing.T) {
+	dispatcher := NewDispatcher()
+
+	assert.NotNil(t, dispatcher)
+	assert.False(t, dispatcher.HasHandlers(stripe.EventTypePaymentIntentSucceeded))
+	assert.NoError(t, dispatcher.Dispatch(context.Background(), newTestEvent("product.created", nil)))
+}
+
+func TestDispatcher_FanOut(t *testing.T) {
+	dispatcher := NewDispatcher()
+
+	var calls []string
+	dispatcher.Register(stripe.EventTypePaymentIntentSucceeded, func(ctx context.Context, event stripe.Event) error {
+		calls = append(calls, "first")
+		return nil
+	})
+	dispatcher.Register(stripe.EventTypePaymentIntentSucceeded, func(ctx context.Context, event stripe.Event) error {
+		calls = append(calls, "second")
+		return nil
+	})
+	dispatcher.Register(stripe.EventTypeInvoicePaid, func(ctx context.Context, event stripe.Event) error {
+		calls = append(calls, "other")
+		return nil
+	})
+
+	err := dispatcher.Dispatch(context.Background(), newTestEvent(stripe.EventTypePaymentIntentSucceeded, nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, calls)
+	assert.True(t, dispatcher.HasHandlers(stripe.EventTypePaymentIntentSucceeded))
+}
+
+func TestDispatcher_ErrorIsolation(t *testing.T) {
+	dispatcher := NewDispatcher()
+	errFirst := errors.New("first failed")
+
+	secondCalled := false
+	thirdCalled := false
+	dispatcher.Register(stripe.EventTypeInvoicePaymentFailed, func(ctx context.Context, event stripe.Event) error {
+		return errFirst
+	})
+	dispatcher.Register(stripe.EventTypeInvoicePaymentFailed, func(ctx context.Context, event stripe.Event) error {
+		secondCalled = true
+		panic("boom")
+	})
+	dispatcher.Register(stripe.EventTypeInvoicePaymentFailed, func(ctx context.Context, event stripe.Event) error {
+		thirdCalled = true
+		return nil
+	})
+
+	err := dispatcher.Dispatch(context.Background(), newTestEvent(stripe.EventTypeInvoicePaymentFailed, nil))
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errFirst)
+	assert.Contains(t, err.Error(), "handler panicked: boom")
+	assert.True(t, secondCalled, "Expected second handler to run after first failed")
+	assert.True(t, thirdCalled, "Expected third handler to run after second panicked")
+	assert.Equal(t, []int{0, 1}, FailedHandlers(err))
+}
+
+func TestDispatcher_DispatchHandlers(t *testing.T) {
+	dispatcher := NewDispatcher()
+
+	var called []int
+	for i := 0; i < 3; i++ {
+		i := i
+		dispatcher.Register(stripe.EventTypeInvoicePaymentFailed, func(ctx context.Context, event stripe.Event) error {
+			called = append(called, i)
+			if i == 2 {
+				return errors.New("still failing")
+			}
+			return nil
+		})
+	}
+	event := newTestEvent(stripe.EventTypeInvoicePaymentFailed, nil)
+
+	err := dispatcher.DispatchHandlers(context.Background(), event, []int{1, 2, 7})
+	require.Error(t, err)
+	assert.Equal(t, []int{1, 2}, called, "Expected only the requested handlers to run")
+	assert.Equal(t, []int{2}, FailedHandlers(err))
+
+	called = nil
+	require.Error(t, dispatcher.DispatchHandlers(context.Background(), event, nil))
+	assert.Equal(t, []int{0, 1, 2}, called, "Expected every handler to run without indexes")
+
+	assert.Nil(t, FailedHandlers(nil))
+	assert.Nil(t, FailedHandlers(errors.New("unhandled hook failed")))
+}
+
+func TestDispatcher_OnUnhandled(t *testing.T) {
+	dispatcher := NewDispatcher()
+
+	var unhandledType stripe.EventType
+	dispatcher.OnUnhandled(func(ctx context.Context, event stripe.Event) error {
+		unhandledType = event.Type
+		return nil
+	})
+	dispatcher.Register(stripe.EventTypeInvoicePaid, func(ctx context.Context, event stripe.Event) error {
+		return nil
+	})
+
+	require.NoError(t, dispatcher.Dispatch(context.Background(), newTestEvent(stripe.EventTypeInvoicePaid, nil)))
+	assert.Empty(t, unhandledType, "Expected unhandled hook not to run for handled events")
+
+	require.NoError(t, dispatcher.Dispatch(context.Background(), newTestEvent(stripe.EventTypeProductCreated, nil)))
+	assert.Equal(t, stripe.EventTypeProductCreated, unhandledType)
+
+	dispatcher.OnUnhandled(nil)
+	assert.NoError(t, dispatcher.Dispatch(context.Background(), newTestEvent(stripe.EventTypeProductCreated, nil)))
+}
+
+func TestObjectHandler(t *testing.T) {
+	var received *stripe.PaymentIntent
+	handler := ObjectHandler(func(ctx context.Context, event stripe.Event, paymentIntent *stripe.PaymentIntent) error {
+		received = paymentIntent
+		return nil
+	})
+
+	event := newTestEvent(stripe.EventTypePaymentIntentSucceeded, map[string]interface{}{
+		"id":     "pi_test123",
+		"amount": 1000,
+	})
+	require.NoError(t, handler(context.Background(), event))
+	require.NotNil(t, received)
+	assert.Equal(t, "pi_test123", received.ID)
+	assert.Equal(t, int64(1000), received.Amount)
+
+	badEvent := newTestEvent(stripe.EventTypePaymentIntentSucceeded, map[string]interface{}{
+		"amount": "not-a-number",
+	})
+	assert.Error(t, handler(context.Background(), badEvent))
+
+	assert.Error(t, handler(context.Background(), stripe.Event{ID: "evt_nodata"}))
+}
+
+func TestRegisterLoggingHandlers(t *testing.T) {
+	dispatcher := NewDispatcher()
+	RegisterLoggingHandlers(dispatcher)
+
+	for _, eventType := range []stripe.EventType{
+		stripe.EventTypePaymentIntentSucceeded,
+		stripe.EventTypeChargeDisputeCreated,
+		stripe.EventTypeChargeDisputeClosed,
+		stripe.EventTypeCustomerSubscriptionUpdated,
+		stripe.EventTypeInvoicePaymentFailed,
+	} {
+		assert.True(t, dispatcher.HasHandlers(eventType), "Expected handler for %s", eventType)
+	}
+}
@@ -0,0 +1,127 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/stripe/stripe-go/v76"
+)
+
+// ErrEventInProgress is returned when the same event is already being handled
+var ErrEventInProgress = errors.New("event is already being processed")
+
+// Processor dispatches each Stripe event at most once successfully, using the
+// ledger to skip redeliveries and to remember failures for replay
+type Processor struct {
+	dispatcher *Dispatcher
+	store      EventStore
+
+	mu       sync.Mutex
+	inFlight map[string]struct{}
+}
+
+// NewProcessor creates a processor backed by the given dispatcher and ledger
+func NewProcessor(dispatcher *Dispatcher, store EventStore) *Processor {
+	return &Processor{
+		dispatcher: dispatcher,
+		store:      store,
+		inFlight:   make(map[string]struct{}),
+	}
+}
+
+// Process dispatches the event unless it was already processed. It reports
+// duplicate=true without running handlers for events already in the ledger.
+// payload is the raw verified request body, stored on failure for replay.
+func (p *Processor) Process(ctx context.Context, event stripe.Event, payload []byte) (duplicate bool, err error) {
+	if !p.begin(event.ID) {
+		return false, ErrEventInProgress
+	}
+	defer p.end(event.ID)
+
+	record, err := p.store.Get(ctx, event.ID)
+	if err != nil && !errors.Is(err, ErrEventNotFound) {
+		return false, fmt.Errorf("failed to read event ledger: %w", err)
+	}
+	if record != nil && record.Status == EventStatusProcessed {
+		return true, nil
+	}
+
+	var handlers []int
+	if record != nil {
+		handlers = record.FailedHandlers
+	}
+	return false, p.dispatch(ctx, event, payload, handlers)
+}
+
+// ReplayFailed re-runs the handlers that failed for every event whose last
+// attempt failed and returns how many of those events now succeeded. Handlers
+// that already succeeded for an event are not run again.
+func (p *Processor) ReplayFailed(ctx context.Context) (int, error) {
+	failed, err := p.store.ListFailed(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list failed events: %w", err)
+	}
+
+	replayed := 0
+	var errs []error
+	for _, record := range failed {
+		var event stripe.Event
+		if err := json.Unmarshal(record.Payload, &event); err != nil {
+			errs = append(errs, fmt.Errorf("event %s: failed to decode stored payload: %w", record.ID, err))
+			continue
+		}
+
+		if !p.begin(event.ID) {
+			continue
+		}
+		err := p.dispatch(ctx, event, record.Payload, record.FailedHandlers)
+		p.end(event.ID)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("event %s: %w", record.ID, err))
+			continue
+		}
+		replayed++
+	}
+
+	return replayed, errors.Join(errs...)
+}
+
+// dispatch runs the given handlers (all of them when handlers is empty) and
+// records the outcome in the ledger
+func (p *Processor) dispatch(ctx context.Context, event stripe.Event, payload []byte, handlers []int) error {
+	if dispatchErr := p.dispatcher.DispatchHandlers(ctx, event, handlers); dispatchErr != nil {
+		if err := p.store.MarkFailed(ctx, event, payload, dispatchErr); err != nil {
+			log.Printf("Webhook ledger error - EventID: %s, Error: %v", event.ID, err)
+		}
+		return dispatchErr
+	}
+
+	if err := p.store.MarkProcessed(ctx, event); err != nil {
+		// Handlers already ran; a redelivery may run them again
+		log.Printf("Webhook ledger error - EventID: %s, Error: %v", event.ID, err)
+	}
+	return nil
+}
+
+func (p *Processor) begin(eventID string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, busy := p.inFlight[eventID]; busy {
+		return false
+	}
+	p.inFlight[eventID] = struct{}{}
+	return true
+}
+
+func (p *Processor) end(eventID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.inFlight, eventID)
+}
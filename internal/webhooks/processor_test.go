@@ -0,0 +1,153 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v76"
+)
+
+func TestProcessor_SkipsDuplicates(t *testing.T) {
+	dispatcher := NewDispatcher()
+	calls := 0
+	dispatcher.Register(stripe.EventTypePaymentIntentSucceeded, func(ctx context.Context, event stripe.Event) error {
+		calls++
+		return nil
+	})
+	processor := NewProcessor(dispatcher, NewMemoryStore())
+	event := newTestEvent(stripe.EventTypePaymentIntentSucceeded, nil)
+
+	duplicate, err := processor.Process(context.Background(), event, nil)
+	require.NoError(t, err)
+	assert.False(t, duplicate)
+
+	duplicate, err = processor.Process(context.Background(), event, nil)
+	require.NoError(t, err)
+	assert.True(t, duplicate)
+
+	assert.Equal(t, 1, calls, "Expected handlers to run exactly once")
+}
+
+func TestProcessor_RetriesFailedEvents(t *testing.T) {
+	dispatcher := NewDispatcher()
+	errDownstream := errors.New("downstream unavailable")
+	fail := true
+	dispatcher.Register(stripe.EventTypeInvoicePaymentFailed, func(ctx context.Context, event stripe.Event) error {
+		if fail {
+			return errDownstream
+		}
+		return nil
+	})
+	store := NewMemoryStore()
+	processor := NewProcessor(dispatcher, store)
+	event := newTestEvent(stripe.EventTypeInvoicePaymentFailed, nil)
+
+	_, err := processor.Process(context.Background(), event, []byte(`{"id":"evt_test123"}`))
+	assert.ErrorIs(t, err, errDownstream)
+
+	record, err := store.Get(context.Background(), event.ID)
+	require.NoError(t, err)
+	assert.Equal(t, EventStatusFailed, record.Status)
+
+	// A redelivery of a failed event runs the handlers again
+	fail = false
+	duplicate, err := processor.Process(context.Background(), event, nil)
+	require.NoError(t, err)
+	assert.False(t, duplicate)
+
+	record, err = store.Get(context.Background(), event.ID)
+	require.NoError(t, err)
+	assert.Equal(t, EventStatusProcessed, record.Status)
+}
+
+func TestProcessor_ReplayFailed(t *testing.T) {
+	dispatcher := NewDispatcher()
+	fail := true
+	var replayedIDs []string
+	dispatcher.Register(stripe.EventTypeInvoicePaymentFailed, func(ctx context.Context, event stripe.Event) error {
+		if fail {
+			return errors.New("downstream unavailable")
+		}
+		replayedIDs = append(replayedIDs, event.ID)
+		return nil
+	})
+	store := NewMemoryStore()
+	processor := NewProcessor(dispatcher, store)
+
+	payload := []byte(`{"id":"evt_replay","object":"event","type":"invoice.payment_failed","data":{"object":{"id":"in_123"}}}`)
+	event := stripe.Event{ID: "evt_replay", Type: stripe.EventTypeInvoicePaymentFailed}
+	_, err := processor.Process(context.Background(), event, payload)
+	require.Error(t, err)
+
+	fail = false
+	replayed, err := processor.ReplayFailed(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, replayed)
+	assert.Equal(t, []string{"evt_replay"}, replayedIDs)
+
+	failed, err := store.ListFailed(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, failed)
+
+	replayed, err = processor.ReplayFailed(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, replayed)
+}
+
+func TestProcessor_ReplayRunsOnlyFailedHandlers(t *testing.T) {
+	dispatcher := NewDispatcher()
+	fail := true
+	succeededCalls, failedCalls := 0, 0
+	dispatcher.Register(stripe.EventTypeInvoicePaymentFailed, func(ctx context.Context, event stripe.Event) error {
+		succeededCalls++
+		return nil
+	})
+	dispatcher.Register(stripe.EventTypeInvoicePaymentFailed, func(ctx context.Context, event stripe.Event) error {
+		failedCalls++
+		if fail {
+			return errors.New("downstream unavailable")
+		}
+		return nil
+	})
+	store := NewMemoryStore()
+	processor := NewProcessor(dispatcher, store)
+
+	payload := []byte(`{"id":"evt_partial","object":"event","type":"invoice.payment_failed","data":{"object":{"id":"in_123"}}}`)
+	event := stripe.Event{ID: "evt_partial", Type: stripe.EventTypeInvoicePaymentFailed}
+	_, err := processor.Process(context.Background(), event, payload)
+	require.Error(t, err)
+
+	record, err := store.Get(context.Background(), event.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, record.FailedHandlers)
+
+	// A redelivery while the handler is still failing retries only that handler
+	_, err = processor.Process(context.Background(), event, payload)
+	require.Error(t, err)
+
+	fail = false
+	replayed, err := processor.ReplayFailed(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, replayed)
+
+	assert.Equal(t, 1, succeededCalls, "Expected the succeeded handler not to run again")
+	assert.Equal(t, 3, failedCalls)
+
+	record, err = store.Get(context.Background(), event.ID)
+	require.NoError(t, err)
+	assert.Equal(t, EventStatusProcessed, record.Status)
+	assert.Empty(t, record.FailedHandlers)
+}
+
+func TestProcessor_RejectsConcurrentDelivery(t *testing.T) {
+	processor := NewProcessor(NewDispatcher(), NewMemoryStore())
+
+	require.True(t, processor.begin("evt_test123"))
+	defer processor.end("evt_test123")
+
+	_, err := processor.Process(context.Background(), newTestEvent(stripe.EventTypeInvoicePaid, nil), nil)
+	assert.ErrorIs(t, err, ErrEventInProgress)
+}
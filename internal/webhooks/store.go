@@ -0,0 +1,315 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stripe/stripe-go/v76"
+)
+
+// Event processing states recorded in the ledger
+const (
+	EventStatusProcessed = "processed"
+	EventStatusFailed    = "failed"
+)
+
+// DefaultRetention is how long ledger entries are kept after their last update.
+// It comfortably covers Stripe's three-day retry window for redeliveries.
+// Failed entries past the window are dropped as well and can no longer be replayed.
+const DefaultRetention = 30 * 24 * time.Hour
+
+// ErrEventNotFound is returned when the ledger has no record of an event
+var ErrEventNotFound = errors.New("event not found")
+
+// ProcessedEvent is a ledger entry describing the outcome of handling an event
+type ProcessedEvent struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Status    string `json:"status"`
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error,omitempty"`
+	// FailedHandlers lists the registration indexes of the handlers that
+	// failed on the latest attempt; empty means the whole event is retried
+	FailedHandlers []int           `json:"failed_handlers,omitempty"`
+	Payload        json.RawMessage `json:"payload,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// EventStore persists the processed-event ledger keyed by Stripe event ID
+type EventStore interface {
+	// Get returns the ledger entry for an event, or ErrEventNotFound
+	Get(ctx context.Context, eventID string) (*ProcessedEvent, error)
+	// MarkProcessed records that all handlers for the event succeeded
+	MarkProcessed(ctx context.Context, event stripe.Event) error
+	// MarkFailed records a failed attempt along with the payload needed to replay
+	// it and the handlers that failed, as reported by FailedHandlers(cause)
+	MarkFailed(ctx context.Context, event stripe.Event, payload []byte, cause error) error
+	// ListFailed returns events whose latest attempt failed, oldest first
+	ListFailed(ctx context.Context) ([]ProcessedEvent, error)
+}
+
+// MemoryStore is an in-process EventStore; its ledger is lost on restart
+type MemoryStore struct {
+	mu        sync.RWMutex
+	events    map[string]ProcessedEvent
+	retention time.Duration
+}
+
+// NewMemoryStore creates an empty in-memory event store that keeps entries
+// for DefaultRetention
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		events:    make(map[string]ProcessedEvent),
+		retention: DefaultRetention,
+	}
+}
+
+// SetRetention changes how long entries are kept after their last update.
+// Zero or a negative value disables pruning.
+func (s *MemoryStore) SetRetention(retention time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.retention = retention
+}
+
+// Get returns the ledger entry for an event
+func (s *MemoryStore) Get(ctx context.Context, eventID string) (*ProcessedEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	record, ok := s.events[eventID]
+	if !ok {
+		return nil, ErrEventNotFound
+	}
+	return &record, nil
+}
+
+// MarkProcessed records a successfully handled event
+func (s *MemoryStore) MarkProcessed(ctx context.Context, event stripe.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events[event.ID] = markProcessed(s.events[event.ID], event)
+	pruneExpired(s.events, s.retention)
+	return nil
+}
+
+// MarkFailed records a failed attempt for an event
+func (s *MemoryStore) MarkFailed(ctx context.Context, event stripe.Event, payload []byte, cause error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events[event.ID] = markFailed(s.events[event.ID], event, payload, cause)
+	pruneExpired(s.events, s.retention)
+	return nil
+}
+
+// ListFailed returns events whose latest attempt failed
+func (s *MemoryStore) ListFailed(ctx context.Context) ([]ProcessedEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return failedEvents(s.events), nil
+}
+
+// FileStore is an EventStore persisted as a JSON file, suitable for a single instance
+type FileStore struct {
+	mu        sync.Mutex
+	path      string
+	events    map[string]ProcessedEvent
+	retention time.Duration
+}
+
+// NewFileStore opens the ledger at path, creating it on first write. Entries
+// older than DefaultRetention are dropped on load and on every write.
+func NewFileStore(path string) (*FileStore, error) {
+	store := &FileStore{
+		path:      path,
+		events:    make(map[string]ProcessedEvent),
+		retention: DefaultRetention,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event store: %w", err)
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &store.events); err != nil {
+			return nil, fmt.Errorf("failed to parse event store: %w", err)
+		}
+	}
+	pruneExpired(store.events, store.retention)
+
+	return store, nil
+}
+
+// SetRetention changes how long entries are kept after their last update; the
+// new window applies from the next write. Zero or a negative value disables
+// pruning.
+func (s *FileStore) SetRetention(retention time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.retention = retention
+}
+
+// Get returns the ledger entry for an event
+func (s *FileStore) Get(ctx context.Context, eventID string) (*ProcessedEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.events[eventID]
+	if !ok {
+		return nil, ErrEventNotFound
+	}
+	return &record, nil
+}
+
+// MarkProcessed records a successfully handled event and flushes the ledger
+func (s *FileStore) MarkProcessed(ctx context.Context, event stripe.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.events[event.ID]
+	s.events[event.ID] = markProcessed(previous, event)
+	if err := s.flush(); err != nil {
+		s.restore(event.ID, previous, existed)
+		return err
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt for an event and flushes the ledger
+func (s *FileStore) MarkFailed(ctx context.Context, event stripe.Event, payload []byte, cause error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.events[event.ID]
+	s.events[event.ID] = markFailed(previous, event, payload, cause)
+	if err := s.flush(); err != nil {
+		s.restore(event.ID, previous, existed)
+		return err
+	}
+	return nil
+}
+
+// ListFailed returns events whose latest attempt failed
+func (s *FileStore) ListFailed(ctx context.Context) ([]ProcessedEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return failedEvents(s.events), nil
+}
+
+// flush prunes expired entries and atomically rewrites the ledger file;
+// callers must hold s.mu
+func (s *FileStore) flush() error {
+	pruneExpired(s.events, s.retention)
+
+	data, err := json.Marshal(s.events)
+	if err != nil {
+		return fmt.Errorf("failed to encode event store: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write event store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write event store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write event store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write event store: %w", err)
+	}
+	return nil
+}
+
+// restore undoes an in-memory change after a failed flush; callers must hold s.mu
+func (s *FileStore) restore(eventID string, previous ProcessedEvent, existed bool) {
+	if existed {
+		s.events[eventID] = previous
+	} else {
+		delete(s.events, eventID)
+	}
+}
+
+func markProcessed(record ProcessedEvent, event stripe.Event) ProcessedEvent {
+	record = touch(record, event)
+	record.Status = EventStatusProcessed
+	record.LastError = ""
+	record.FailedHandlers = nil
+	record.Payload = nil
+	return record
+}
+
+func markFailed(record ProcessedEvent, event stripe.Event, payload []byte, cause error) ProcessedEvent {
+	record = touch(record, event)
+	record.Status = EventStatusFailed
+	if cause != nil {
+		record.LastError = cause.Error()
+	}
+	record.FailedHandlers = FailedHandlers(cause)
+	if len(payload) > 0 {
+		record.Payload = append(json.RawMessage(nil), payload...)
+	}
+	return record
+}
+
+func touch(record ProcessedEvent, event stripe.Event) ProcessedEvent {
+	now := time.Now().UTC()
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = now
+	}
+	record.ID = event.ID
+	record.Type = string(event.Type)
+	record.Attempts++
+	record.UpdatedAt = now
+	return record
+}
+
+// pruneExpired drops entries not updated within the retention window
+func pruneExpired(events map[string]ProcessedEvent, retention time.Duration) {
+	if retention <= 0 {
+		return
+	}
+
+	cutoff := time.Now().UTC().Add(-retention)
+	for id, record := range events {
+		if record.UpdatedAt.Before(cutoff) {
+			delete(events, id)
+		}
+	}
+}
+
+func failedEvents(events map[string]ProcessedEvent) []ProcessedEvent {
+	var failed []ProcessedEvent
+	for _, record := range events {
+		if record.Status == EventStatusFailed {
+			failed = append(failed, record)
+		}
+	}
+
+	sort.Slice(failed, func(i, j int) bool {
+		return failed[i].CreatedAt.Before(failed[j].CreatedAt)
+	})
+	return failed
+}
@@ -0,0 +1,147 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v76"
+)
+
+func testEventStores(t *testing.T) map[string]EventStore {
+	fileStore, err := NewFileStore(filepath.Join(t.TempDir(), "events.json"))
+	require.NoError(t, err)
+
+	return map[string]EventStore{
+		"memory": NewMemoryStore(),
+		"file":   fileStore,
+	}
+}
+
+func TestEventStore_Ledger(t *testing.T) {
+	for name, store := range testEventStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			event := newTestEvent(stripe.EventTypePaymentIntentSucceeded, nil)
+
+			_, err := store.Get(ctx, event.ID)
+			assert.ErrorIs(t, err, ErrEventNotFound)
+
+			cause := &HandlerError{Index: 1, EventType: event.Type, Err: errors.New("boom")}
+			require.NoError(t, store.MarkFailed(ctx, event, []byte(`{"id":"evt_test123"}`), cause))
+
+			record, err := store.Get(ctx, event.ID)
+			require.NoError(t, err)
+			assert.Equal(t, EventStatusFailed, record.Status)
+			assert.Equal(t, cause.Error(), record.LastError)
+			assert.Equal(t, []int{1}, record.FailedHandlers)
+			assert.Equal(t, 1, record.Attempts)
+			assert.JSONEq(t, `{"id":"evt_test123"}`, string(record.Payload))
+
+			failed, err := store.ListFailed(ctx)
+			require.NoError(t, err)
+			require.Len(t, failed, 1)
+			assert.Equal(t, event.ID, failed[0].ID)
+
+			require.NoError(t, store.MarkProcessed(ctx, event))
+
+			record, err = store.Get(ctx, event.ID)
+			require.NoError(t, err)
+			assert.Equal(t, EventStatusProcessed, record.Status)
+			assert.Equal(t, 2, record.Attempts)
+			assert.Empty(t, record.LastError)
+			assert.Empty(t, record.FailedHandlers)
+			assert.Empty(t, record.Payload)
+
+			failed, err = store.ListFailed(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, failed)
+		})
+	}
+}
+
+func TestEventStore_PrunesExpiredEntries(t *testing.T) {
+	ctx := context.Background()
+	fileStore, err := NewFileStore(filepath.Join(t.TempDir(), "events.json"))
+	require.NoError(t, err)
+	memoryStore := NewMemoryStore()
+
+	stale := ProcessedEvent{
+		ID:        "evt_stale",
+		Status:    EventStatusProcessed,
+		UpdatedAt: time.Now().UTC().Add(-DefaultRetention - time.Hour),
+	}
+	fileStore.events[stale.ID] = stale
+	memoryStore.events[stale.ID] = stale
+
+	for name, store := range map[string]EventStore{"memory": memoryStore, "file": fileStore} {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, store.MarkProcessed(ctx, newTestEvent(stripe.EventTypeInvoicePaid, nil)))
+
+			_, err := store.Get(ctx, stale.ID)
+			assert.ErrorIs(t, err, ErrEventNotFound)
+
+			_, err = store.Get(ctx, "evt_test123")
+			assert.NoError(t, err)
+		})
+	}
+
+	reopened, err := NewFileStore(fileStore.path)
+	require.NoError(t, err)
+	_, err = reopened.Get(ctx, stale.ID)
+	assert.ErrorIs(t, err, ErrEventNotFound)
+}
+
+func TestFileStore_PrunesOnLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	stale := time.Now().UTC().Add(-DefaultRetention - time.Hour).Format(time.RFC3339)
+	data := `{"evt_stale":{"id":"evt_stale","status":"processed","updated_at":"` + stale + `"}}`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+	store, err := NewFileStore(path)
+	require.NoError(t, err)
+
+	_, err = store.Get(context.Background(), "evt_stale")
+	assert.ErrorIs(t, err, ErrEventNotFound)
+}
+
+func TestMemoryStore_RetentionDisabled(t *testing.T) {
+	store := NewMemoryStore()
+	store.SetRetention(0)
+	store.events["evt_stale"] = ProcessedEvent{ID: "evt_stale", Status: EventStatusProcessed}
+
+	require.NoError(t, store.MarkProcessed(context.Background(), newTestEvent(stripe.EventTypeInvoicePaid, nil)))
+
+	_, err := store.Get(context.Background(), "evt_stale")
+	assert.NoError(t, err)
+}
+
+func TestFileStore_PersistsAcrossReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.json")
+
+	store, err := NewFileStore(path)
+	require.NoError(t, err)
+	require.NoError(t, store.MarkProcessed(ctx, newTestEvent(stripe.EventTypeInvoicePaid, nil)))
+
+	reopened, err := NewFileStore(path)
+	require.NoError(t, err)
+
+	record, err := reopened.Get(ctx, "evt_test123")
+	require.NoError(t, err)
+	assert.Equal(t, EventStatusProcessed, record.Status)
+	assert.Equal(t, string(stripe.EventTypeInvoicePaid), record.Type)
+}
+
+func TestNewFileStore_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+
+	_, err := NewFileStore(path)
+	assert.Error(t, err)
+}
@@ -14,6 +14,7 @@ import (
 	"stripe-service/internal/handlers"
 	"stripe-service/internal/server"
 	"stripe-service/internal/service"
+	"stripe-service/internal/webhooks"
 )
 
 func main() {
@@ -24,15 +25,34 @@ func main() {
 	if cfg.Stripe.SecretKey == "" {
 		log.Fatal("STRIPE_SECRET_KEY environment variable is required")
 	}
+	if cfg.Stripe.WebhookSecret == "" {
+		log.Println("⚠️  STRIPE_WEBHOOK_SECRET is not set; webhook events will be rejected")
+	}
 
 	// Initialize services
 	stripeService := service.NewStripeService(cfg)
 
+	// Register webhook event handlers
+	webhookDispatcher := webhooks.NewDispatcher()
+	webhooks.RegisterLoggingHandlers(webhookDispatcher)
+
+	var eventStore webhooks.EventStore = webhooks.NewMemoryStore()
+	if cfg.Stripe.WebhookEventStorePath != "" {
+		fileStore, err := webhooks.NewFileStore(cfg.Stripe.WebhookEventStorePath)
+		if err != nil {
+			log.Fatalf("Failed to open webhook event store: %v", err)
+		}
+		eventStore = fileStore
+	}
+	webhookProcessor := webhooks.NewProcessor(webhookDispatcher, eventStore)
+
 	// Initialize handlers
 	stripeHandler := handlers.NewStripeHandler(stripeService)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhookProcessor)
+	webhookHandler.SetReplayToken(cfg.Stripe.WebhookReplayToken)
 
 	// Initialize server
-	srv := server.NewServer(stripeHandler)
+	srv := server.NewServer(stripeHandler, webhookHandler)
 
 	// Setup HTTP server
 	httpServer := &http.Server{
@@ -12,6 +12,7 @@ import (
 	"stripe-service/internal/handlers"
 	"stripe-service/internal/server"
 	"stripe-service/internal/service"
+	"stripe-service/internal/webhooks"
 )
 
 // Test the main application components integration
@@ -40,12 +41,13 @@ func TestMainApplicationIntegration(t *testing.T) {
 
 	// Initialize handlers
 	stripeHandler := handlers.NewStripeHandler(stripeService)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
 	if stripeHandler == nil {
 		t.Fatal("Failed to create stripe handler")
 	}
 
 	// Initialize server
-	srv := server.NewServer(stripeHandler)
+	srv := server.NewServer(stripeHandler, webhookHandler)
 	if srv == nil {
 		t.Fatal("Failed to create server")
 	}
@@ -135,7 +137,8 @@ func TestServerStartupComponents(t *testing.T) {
 	cfg := config.Load()
 	stripeService := service.NewStripeService(cfg)
 	stripeHandler := handlers.NewStripeHandler(stripeService)
-	srv := server.NewServer(stripeHandler)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
+	srv := server.NewServer(stripeHandler, webhookHandler)
 
 	// Test that the server handler is properly set up
 	handler := srv.Handler()
@@ -238,7 +241,8 @@ func TestHTTPServerConfiguration(t *testing.T) {
 	cfg := config.Load()
 	stripeService := service.NewStripeService(cfg)
 	stripeHandler := handlers.NewStripeHandler(stripeService)
-	srv := server.NewServer(stripeHandler)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
+	srv := server.NewServer(stripeHandler, webhookHandler)
 
 	// Create HTTP server with the same configuration as main()
 	httpServer := &http.Server{
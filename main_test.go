@@ -9,6 +9,7 @@ import (
 	"stripe-service/internal/handlers"
 	"stripe-service/internal/server"
 	"stripe-service/internal/service"
+	"stripe-service/internal/webhooks"
 )
 
 func TestServerCreation(t *testing.T) {
@@ -20,9 +21,10 @@ func TestServerCreation(t *testing.T) {
 	}
 	stripeService := service.NewStripeService(cfg)
 	stripeHandler := handlers.NewStripeHandler(stripeService)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
 
 	// Create server
-	srv := server.NewServer(stripeHandler)
+	srv := server.NewServer(stripeHandler, webhookHandler)
 
 	if srv == nil {
 		t.Error("Expected server to be created, got nil")
@@ -43,9 +45,10 @@ func TestHealthEndpoint(t *testing.T) {
 	}
 	stripeService := service.NewStripeService(cfg)
 	stripeHandler := handlers.NewStripeHandler(stripeService)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
 
 	// Create server
-	srv := server.NewServer(stripeHandler)
+	srv := server.NewServer(stripeHandler, webhookHandler)
 
 	// Create a test request
 	req, err := http.NewRequest("GET", "/api/v1/health", nil)
@@ -80,9 +83,10 @@ func TestLoggingMiddleware(t *testing.T) {
 	}
 	stripeService := service.NewStripeService(cfg)
 	stripeHandler := handlers.NewStripeHandler(stripeService)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
 
 	// Create server (which includes logging middleware)
-	srv := server.NewServer(stripeHandler)
+	srv := server.NewServer(stripeHandler, webhookHandler)
 
 	// Create a test request
 	req, err := http.NewRequest("GET", "/api/v1/health", nil)
@@ -111,9 +115,10 @@ func TestCORSMiddleware(t *testing.T) {
 	}
 	stripeService := service.NewStripeService(cfg)
 	stripeHandler := handlers.NewStripeHandler(stripeService)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
 
 	// Create server (which includes CORS middleware)
-	srv := server.NewServer(stripeHandler)
+	srv := server.NewServer(stripeHandler, webhookHandler)
 
 	// Test OPTIONS request
 	req, err := http.NewRequest("OPTIONS", "/api/v1/customers", nil)
@@ -152,9 +157,10 @@ func TestResponseWriterWrapper(t *testing.T) {
 	}
 	stripeService := service.NewStripeService(cfg)
 	stripeHandler := handlers.NewStripeHandler(stripeService)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
 
 	// Create server
-	srv := server.NewServer(stripeHandler)
+	srv := server.NewServer(stripeHandler, webhookHandler)
 
 	// Create a test request
 	req, err := http.NewRequest("GET", "/api/v1/health", nil)
@@ -183,9 +189,10 @@ func TestServerIntegration(t *testing.T) {
 	}
 	stripeService := service.NewStripeService(cfg)
 	stripeHandler := handlers.NewStripeHandler(stripeService)
+	webhookHandler := handlers.NewWebhookHandler(cfg.Stripe.WebhookSecret, webhooks.NewProcessor(webhooks.NewDispatcher(), webhooks.NewMemoryStore()))
 
 	// Create server
-	srv := server.NewServer(stripeHandler)
+	srv := server.NewServer(stripeHandler, webhookHandler)
 
 	// Test various endpoints
 	endpoints := []struct {
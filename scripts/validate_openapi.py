@@ -67,7 +67,45 @@ def validate_paths(spec: Dict[str, Any]) -> List[str]:
         '/products',
         '/prices',
         '/subscriptions',
-        '/subscriptions/{id}'
+        '/subscriptions/{id}',
+        '/webhooks/stripe',
+        '/webhooks/stripe/replay',
+        '/payment-intents/{id}',
+        '/payment-intents/{id}/cancel',
+        '/payment-intents/{id}/capture',
+        '/refunds',
+        '/refunds/{id}',
+        '/refunds/{id}/cancel',
+        '/disputes',
+        '/disputes/{id}',
+        '/disputes/{id}/evidence',
+        '/disputes/{id}/close',
+        '/customers/{id}/payment-methods',
+        '/customers/{id}/default-payment-method',
+        '/payment-methods/{id}',
+        '/payment-methods/{id}/attach',
+        '/payment-methods/{id}/detach',
+        '/setup-intents',
+        '/setup-intents/{id}',
+        '/setup-intents/{id}/confirm',
+        '/setup-intents/{id}/cancel',
+        '/checkout/sessions',
+        '/checkout/sessions/{id}',
+        '/checkout/sessions/{id}/line-items',
+        '/customers/{id}/portal-session',
+        '/portal/configurations',
+        '/portal/configurations/{id}',
+        '/products/{id}',
+        '/products/{id}/archive',
+        '/prices/{id}',
+        '/subscriptions/{id}/pause',
+        '/subscriptions/{id}/resume',
+        '/subscriptions/{id}/undo-cancellation',
+        '/subscriptions/{id}/change-plan',
+        '/subscriptions/{id}/change-plan/preview',
+        '/subscriptions/{id}/items',
+        '/subscriptions/{id}/items/{item_id}',
+        '/subscriptions/{id}/end-trial'
     ]
     
     # Check if all expected paths exist
@@ -127,7 +165,65 @@ def validate_components(spec: Dict[str, Any]) -> List[str]:
         'CreatePriceRequest',
         'Subscription',
         'CreateSubscriptionRequest',
-        'Error'
+        'Error',
+        'WebhookReceipt',
+        'WebhookReplayResult',
+        'UpdateCustomerRequest',
+        'DeletedCustomer',
+        'UpdatePaymentIntentRequest',
+        'CancelPaymentIntentRequest',
+        'CapturePaymentIntentRequest',
+        'ListPaymentIntentsResponse',
+        'Refund',
+        'CreateRefundRequest',
+        'ListRefundsResponse',
+        'Dispute',
+        'DisputeEvidence',
+        'SubmitDisputeEvidenceRequest',
+        'ListDisputesResponse',
+        'PaymentMethod',
+        'PaymentCard',
+        'AttachPaymentMethodRequest',
+        'AttachPaymentMethodError',
+        'SetDefaultPaymentMethodRequest',
+        'ListPaymentMethodsResponse',
+        'SetupIntent',
+        'CreateSetupIntentRequest',
+        'ConfirmSetupIntentRequest',
+        'CancelSetupIntentRequest',
+        'CheckoutSession',
+        'CreateCheckoutSessionRequest',
+        'CheckoutLineItem',
+        'CheckoutPriceData',
+        'CheckoutLineItemSummary',
+        'ListCheckoutLineItemsResponse',
+        'PortalSession',
+        'CreatePortalSessionRequest',
+        'PortalConfiguration',
+        'PortalFeatures',
+        'CreatePortalConfigurationRequest',
+        'UpdatePortalConfigurationRequest',
+        'UpdateProductRequest',
+        'DeletedProduct',
+        'ListProductsResponse',
+        'UpdatePriceRequest',
+        'ListPricesResponse',
+        'PriceTier',
+        'TransformQuantity',
+        'CurrencyOption',
+        'SubscriptionPause',
+        'UpdateSubscriptionRequest',
+        'PauseSubscriptionRequest',
+        'ListSubscriptionsResponse',
+        'ChangePlanRequest',
+        'PlanChangePreview',
+        'ProrationLine',
+        'SubscriptionItem',
+        'SubscriptionItemRequest',
+        'AddSubscriptionItemRequest',
+        'UpdateSubscriptionItemRequest',
+        'DeletedSubscriptionItem',
+        'SubscriptionTrialSettings'
     ]
     
     for schema_name in expected_schemas: